@@ -0,0 +1,8 @@
+# Read a keyspace managed by another stack
+data "scylladb_keyspace" "orders" {
+  name = "orders_ks"
+}
+
+output "orders_tables" {
+  value = data.scylladb_keyspace.orders.tables
+}
@@ -0,0 +1,9 @@
+# List the keyspaces created by users, leaving out those of the cluster
+data "scylladb_keyspaces" "user" {
+  exclude_system = true
+}
+
+# Find the keyspaces that still use vnodes
+output "vnodes_keyspaces" {
+  value = [for k in data.scylladb_keyspaces.user.keyspaces : k.name if !k.tablets.enabled]
+}
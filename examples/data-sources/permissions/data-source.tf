@@ -0,0 +1,13 @@
+# List every permission the app role holds, directly or through other roles
+data "scylladb_permissions" "app" {
+  role              = "app"
+  include_inherited = true
+}
+
+# Assert that the app role cannot drop anything
+check "app_least_privilege" {
+  assert {
+    condition     = alltrue([for p in data.scylladb_permissions.app.permissions : p.permission != "DROP"])
+    error_message = "The app role must not hold the DROP permission."
+  }
+}
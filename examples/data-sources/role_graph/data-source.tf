@@ -0,0 +1,9 @@
+# Resolve who effectively inherits the reader role
+data "scylladb_role_graph" "reader" {
+  role        = "reader"
+  export_json = true
+}
+
+output "reader_inherited_by" {
+  value = data.scylladb_role_graph.reader.transitive_members
+}
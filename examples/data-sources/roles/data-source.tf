@@ -0,0 +1,10 @@
+# List every login role whose name starts with "app-"
+data "scylladb_roles" "apps" {
+  name_regex = "^app-"
+  can_login  = true
+}
+
+# List the roles that were directly granted the reader role
+data "scylladb_roles" "readers" {
+  member_of = "reader"
+}
@@ -0,0 +1,13 @@
+variable "admin_password" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+# Replace cassandra/cassandra with an admin superuser and disable the login
+# of the default role. Point the provider at the admin role afterwards.
+resource "scylladb_default_superuser_lockdown" "this" {
+  superuser                     = "admin"
+  superuser_password_wo         = var.admin_password
+  superuser_password_wo_version = 1
+}
@@ -0,0 +1,26 @@
+# Allow the app role to read every table of a keyspace
+resource "scylladb_grant" "app_select" {
+  role          = "app"
+  permission    = "SELECT"
+  resource_type = "keyspace"
+  keyspace      = "app_ks"
+}
+
+# Allow the app role to write a single table
+resource "scylladb_grant" "app_modify_events" {
+  role          = "app"
+  permission    = "MODIFY"
+  resource_type = "table"
+  keyspace      = "app_ks"
+  table         = "events"
+}
+
+# Allow the app role to execute a function
+resource "scylladb_grant" "app_execute" {
+  role               = "app"
+  permission         = "EXECUTE"
+  resource_type      = "function"
+  keyspace           = "app_ks"
+  function           = "to_celsius"
+  function_arguments = ["double"]
+}
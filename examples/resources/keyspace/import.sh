@@ -0,0 +1,2 @@
+# A keyspace can be imported by specifying its name.
+terraform import scylladb_keyspace.app app_ks
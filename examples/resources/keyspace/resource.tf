@@ -0,0 +1,38 @@
+# A keyspace replicated three times in each of two data centers
+resource "scylladb_keyspace" "app" {
+  name = "app_ks"
+  datacenters = {
+    dc1 = 3
+    dc2 = 3
+  }
+}
+
+# A keyspace for a single data center test cluster
+resource "scylladb_keyspace" "dev" {
+  name               = "dev_ks"
+  replication_class  = "SimpleStrategy"
+  replication_factor = 1
+  durable_writes     = false
+}
+
+# Counters are not supported on tablets, so this keyspace uses vnodes
+resource "scylladb_keyspace" "counters" {
+  name = "counters_ks"
+  datacenters = {
+    dc1 = 3
+  }
+  tablets = {
+    enabled = false
+  }
+}
+
+# Replicas pinned to one rack per availability zone in dc1
+resource "scylladb_keyspace" "zonal" {
+  name = "zonal_ks"
+  datacenter_racks = {
+    dc1 = ["us-east-1a", "us-east-1b", "us-east-1c"]
+  }
+  datacenters = {
+    dc2 = 3
+  }
+}
@@ -4,3 +4,12 @@ resource "scylladb_role" "admin" {
   can_login    = false
   is_superuser = false
 }
+
+# Manage a login role whose password is never stored in the state
+resource "scylladb_role" "app" {
+  role                = "app"
+  can_login           = true
+  password_wo         = var.app_password
+  password_wo_version = 1
+  member_of           = [scylladb_role.admin.role]
+}
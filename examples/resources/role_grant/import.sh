@@ -0,0 +1,3 @@
+# Role grant can be imported by specifying the role and the grantee separated by "|".
+# A "|" or "\" in a name is escaped with a "\".
+terraform import scylladb_role_grant.app_reader 'reader|app'
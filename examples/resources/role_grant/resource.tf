@@ -0,0 +1,5 @@
+# Grant the reader role to the app role
+resource "scylladb_role_grant" "app_reader" {
+  role    = "reader"
+  grantee = "app"
+}
@@ -0,0 +1,2 @@
+# The permissions of a role can be imported by specifying the role name.
+terraform import scylladb_role_permissions.service service
@@ -0,0 +1,19 @@
+# The service account may only read one keyspace and write one table.
+# Any other permission granted to it is revoked.
+resource "scylladb_role_permissions" "service" {
+  role = "service"
+
+  permissions = [
+    {
+      permission    = "SELECT"
+      resource_type = "keyspace"
+      keyspace      = "app_ks"
+    },
+    {
+      permission    = "MODIFY"
+      resource_type = "table"
+      keyspace      = "app_ks"
+      table         = "events"
+    },
+  ]
+}
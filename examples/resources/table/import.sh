@@ -0,0 +1,2 @@
+# A table can be imported by specifying its keyspace and its name separated by a dot.
+terraform import scylladb_table.events app_ks.events
@@ -0,0 +1,91 @@
+resource "scylladb_keyspace" "app" {
+  name = "app_ks"
+  datacenters = {
+    dc1 = 3
+  }
+}
+
+# Events of a tenant, partitioned by day and newest first
+resource "scylladb_table" "events" {
+  keyspace = scylladb_keyspace.app.name
+  name     = "events"
+
+  columns = [
+    { name = "tenant", type = "text" },
+    { name = "day", type = "date" },
+    { name = "ts", type = "timeuuid" },
+    { name = "payload", type = "frozen<map<text, text>>" },
+    { name = "tenant_name", type = "text", static = true },
+  ]
+
+  partition_key = ["tenant", "day"]
+  clustering_key = [
+    { name = "ts", order = "DESC" },
+  ]
+
+  # Tombstones are dropped once repaired, and noisy tenants are throttled
+  tombstone_gc = {
+    mode = "repair"
+  }
+  per_partition_rate_limit = {
+    max_writes_per_second = 1000
+  }
+}
+
+# Metrics kept for a week, compacted in daily windows
+resource "scylladb_table" "metrics" {
+  keyspace = scylladb_keyspace.app.name
+  name     = "metrics"
+
+  columns = [
+    { name = "host", type = "text" },
+    { name = "ts", type = "timestamp" },
+    { name = "value", type = "double" },
+  ]
+
+  partition_key = ["host"]
+  clustering_key = [
+    { name = "ts", order = "DESC" },
+  ]
+
+  comment              = "Raw metrics of each host"
+  default_time_to_live = 604800
+  gc_grace_seconds     = 86400
+  speculative_retry    = "99PERCENTILE"
+
+  compaction = {
+    class = "TimeWindowCompactionStrategy"
+    options = {
+      compaction_window_unit = "DAYS"
+      compaction_window_size = "1"
+    }
+  }
+  compression = {
+    class              = "ZstdCompressor"
+    chunk_length_in_kb = 16
+  }
+  caching = {
+    keys               = "ALL"
+    rows_per_partition = "NONE"
+  }
+}
+
+# Orders streamed to Kafka through CDC, with the full row before each change
+resource "scylladb_table" "orders" {
+  keyspace = scylladb_keyspace.app.name
+  name     = "orders"
+
+  columns = [
+    { name = "id", type = "uuid" },
+    { name = "status", type = "text" },
+    { name = "total", type = "decimal" },
+  ]
+
+  partition_key = ["id"]
+
+  cdc = {
+    enabled  = true
+    preimage = "full"
+    ttl      = 86400
+  }
+}
@@ -178,6 +178,12 @@ func (p *scylladbProvider) Configure(ctx context.Context, req provider.Configure
 func (p *scylladbProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewRoleResource,
+		NewRoleGrantResource,
+		NewGrantResource,
+		NewRolePermissionsResource,
+		NewDefaultSuperuserLockdownResource,
+		NewKeyspaceResource,
+		NewTableResource,
 	}
 }
 
@@ -190,6 +196,11 @@ func (p *scylladbProvider) EphemeralResources(ctx context.Context) []func() ephe
 func (p *scylladbProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewRoleDataSource,
+		NewRolesDataSource,
+		NewRoleGraphDataSource,
+		NewPermissionsDataSource,
+		NewKeyspaceDataSource,
+		NewKeyspacesDataSource,
 	}
 }
 
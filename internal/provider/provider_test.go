@@ -4,8 +4,11 @@
 package provider
 
 import (
+	"testing"
+
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/i1snow/terraform-provider-scylladb/scylladb"
 )
 
 const (
@@ -28,6 +31,19 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 	"scylladb": providerserver.NewProtocol6WithError(New("test")()),
 }
 
+// testAccClient returns a client connected to the test cluster, used to make
+// changes outside of Terraform.
+func testAccClient(t *testing.T, host string) *scylladb.Cluster {
+	cluster := scylladb.NewClusterConfig([]string{host})
+	cluster.SetSystemAuthKeyspace("system")
+	cluster.SetUserPasswordAuth("cassandra", "cassandra")
+	if err := cluster.CreateSession(); err != nil {
+		t.Fatalf("failed to create session: %s", err)
+	}
+	t.Cleanup(cluster.Session.Close)
+	return &cluster
+}
+
 // // testAccProtoV6ProviderFactoriesWithEcho includes the echo provider alongside the scaffolding provider.
 // // It allows for testing assertions on data returned by an ephemeral resource during Open.
 // // The echoprovider is used to arrange tests by echoing ephemeral data into the Terraform state.
@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// keyspaceReplaceAttributes and tableReplaceAttributes are the attributes
+// whose RequiresReplace plan modifier replaces the resource when they change.
+// TestReplaceAttributes checks them against the schemas.
+var (
+	keyspaceReplaceAttributes = []path.Path{
+		path.Root("name"),
+		path.Root("tablets").AtName("enabled"),
+		path.Root("tablets").AtName("initial"),
+	}
+	tableReplaceAttributes = []path.Path{
+		path.Root("keyspace"),
+		path.Root("name"),
+		path.Root("partition_key"),
+		path.Root("clustering_key"),
+	}
+)
+
+// planReplaces reports whether the plan replaces the resource. The framework
+// hands the resource ModifyPlan a response without the replacements required
+// by the attribute plan modifiers, so the attributes that carry them are
+// compared here, along with the paths ModifyPlan itself added.
+func planReplaces(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, attributes []path.Path) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return false, diags
+	}
+	if len(resp.RequiresReplace) > 0 {
+		return true, diags
+	}
+	for _, p := range attributes {
+		var planned, prior attr.Value
+		diags.Append(req.Plan.GetAttribute(ctx, p, &planned)...)
+		diags.Append(req.State.GetAttribute(ctx, p, &prior)...)
+		if diags.HasError() {
+			return false, diags
+		}
+		if !planned.Equal(prior) {
+			return true, diags
+		}
+	}
+	return false, diags
+}
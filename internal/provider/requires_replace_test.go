@@ -0,0 +1,144 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanReplaces(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	(&keyspaceResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	model := func(name string, durableWrites, tablets bool) keyspaceResourceModel {
+		return keyspaceResourceModel{
+			ID:                types.StringValue(name),
+			Name:              types.StringValue(name),
+			ReplicationClass:  types.StringValue("NetworkTopologyStrategy"),
+			ReplicationFactor: types.Int64Null(),
+			DataCenters:       types.MapValueMust(types.Int64Type, map[string]attr.Value{"dc1": types.Int64Value(1)}),
+			DataCenterRacks:   types.MapNull(types.SetType{ElemType: types.StringType}),
+			DurableWrites:     types.BoolValue(durableWrites),
+			Tablets: types.ObjectValueMust(keyspaceTabletsAttrTypes, map[string]attr.Value{
+				"enabled": types.BoolValue(tablets),
+				"initial": types.Int64Value(0),
+			}),
+			DeletionProtection: types.BoolValue(true),
+			ForceDestroy:       types.BoolValue(false),
+		}
+	}
+	replace := func(t *testing.T, prior, planned keyspaceResourceModel, resp *resource.ModifyPlanResponse) bool {
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		plan := tfsdk.Plan{Schema: schemaResp.Schema}
+		config := tfsdk.Config{Schema: schemaResp.Schema}
+		assert.False(t, state.Set(ctx, prior).HasError())
+		assert.False(t, plan.Set(ctx, planned).HasError())
+		config.Raw = plan.Raw
+		replace, diags := planReplaces(ctx, resource.ModifyPlanRequest{Config: config, Plan: plan, State: state}, resp, keyspaceReplaceAttributes)
+		assert.False(t, diags.HasError(), diags)
+		return replace
+	}
+
+	current := model("ks", true, true)
+	assert.False(t, replace(t, current, current, &resource.ModifyPlanResponse{}))
+	assert.False(t, replace(t, current, model("ks", false, true), &resource.ModifyPlanResponse{}))
+	assert.True(t, replace(t, current, model("other", true, true), &resource.ModifyPlanResponse{}))
+	assert.True(t, replace(t, current, model("ks", true, false), &resource.ModifyPlanResponse{}))
+	assert.True(t, replace(t, current, current, &resource.ModifyPlanResponse{RequiresReplace: path.Paths{path.Root("name")}}))
+}
+
+// replaceAttributes returns the attributes of a schema, nested ones included,
+// that have a RequiresReplace plan modifier.
+func replaceAttributes(ctx context.Context, parent path.Path, attributes map[string]schema.Attribute) []string {
+	requiresReplace := stringplanmodifier.RequiresReplace().Description(ctx)
+	var paths []string
+	for name, attribute := range attributes {
+		p := parent.AtName(name)
+		var descriptions []string
+		var nested map[string]schema.Attribute
+		switch a := attribute.(type) {
+		case schema.StringAttribute:
+			for _, m := range a.PlanModifiers {
+				descriptions = append(descriptions, m.Description(ctx))
+			}
+		case schema.BoolAttribute:
+			for _, m := range a.PlanModifiers {
+				descriptions = append(descriptions, m.Description(ctx))
+			}
+		case schema.Int64Attribute:
+			for _, m := range a.PlanModifiers {
+				descriptions = append(descriptions, m.Description(ctx))
+			}
+		case schema.Float64Attribute:
+			for _, m := range a.PlanModifiers {
+				descriptions = append(descriptions, m.Description(ctx))
+			}
+		case schema.MapAttribute:
+			for _, m := range a.PlanModifiers {
+				descriptions = append(descriptions, m.Description(ctx))
+			}
+		case schema.SetAttribute:
+			for _, m := range a.PlanModifiers {
+				descriptions = append(descriptions, m.Description(ctx))
+			}
+		case schema.ListAttribute:
+			for _, m := range a.PlanModifiers {
+				descriptions = append(descriptions, m.Description(ctx))
+			}
+		case schema.ListNestedAttribute:
+			for _, m := range a.PlanModifiers {
+				descriptions = append(descriptions, m.Description(ctx))
+			}
+			nested = a.NestedObject.Attributes
+		case schema.SetNestedAttribute:
+			for _, m := range a.PlanModifiers {
+				descriptions = append(descriptions, m.Description(ctx))
+			}
+			nested = a.NestedObject.Attributes
+		case schema.SingleNestedAttribute:
+			for _, m := range a.PlanModifiers {
+				descriptions = append(descriptions, m.Description(ctx))
+			}
+			nested = a.Attributes
+		}
+		if slices.Contains(descriptions, requiresReplace) {
+			paths = append(paths, p.String())
+		}
+		paths = append(paths, replaceAttributes(ctx, p, nested)...)
+	}
+	slices.Sort(paths)
+	return paths
+}
+
+func TestReplaceAttributes(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range []struct {
+		resource   resource.Resource
+		attributes []path.Path
+	}{
+		{&keyspaceResource{}, keyspaceReplaceAttributes},
+		{&tableResource{}, tableReplaceAttributes},
+	} {
+		var resp resource.SchemaResponse
+		tc.resource.Schema(ctx, resource.SchemaRequest{}, &resp)
+		var expected []string
+		for _, p := range tc.attributes {
+			expected = append(expected, p.String())
+		}
+		slices.Sort(expected)
+		assert.Equal(t, expected, replaceAttributes(ctx, path.Empty(), resp.Schema.Attributes))
+	}
+}
@@ -31,11 +31,12 @@ type roleDataSource struct {
 
 // roleDataSourceModel maps the data source schema data.
 type roleDataSourceModel struct {
-	ID          types.String   `tfsdk:"id"`
-	Role        types.String   `tfsdk:"role"`
-	CanLogin    types.Bool     `tfsdk:"can_login"`
-	IsSuperuser types.Bool     `tfsdk:"is_superuser"`
-	MemberOf    []types.String `tfsdk:"member_of"`
+	ID          types.String            `tfsdk:"id"`
+	Role        types.String            `tfsdk:"role"`
+	CanLogin    types.Bool              `tfsdk:"can_login"`
+	IsSuperuser types.Bool              `tfsdk:"is_superuser"`
+	MemberOf    []types.String          `tfsdk:"member_of"`
+	Options     map[string]types.String `tfsdk:"options"`
 }
 
 // Metadata returns the data source type name.
@@ -63,9 +64,14 @@ func (d *roleDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, r
 				Computed:    true,
 				Description: "whether the role is a superuser",
 			},
-			"member_of": schema.ListAttribute{
+			"member_of": schema.SetAttribute{
 				Computed:    true,
-				Description: "a list of members of the role",
+				Description: "a set of roles granted to the role",
+				ElementType: types.StringType,
+			},
+			"options": schema.MapAttribute{
+				Computed:    true,
+				Description: "custom options of the role, when reported by the role manager",
 				ElementType: types.StringType,
 			},
 		},
@@ -97,6 +103,7 @@ func (d *roleDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		Role:        types.StringValue(curRole.Role),
 		CanLogin:    types.BoolValue(curRole.CanLogin),
 		IsSuperuser: types.BoolValue(curRole.IsSuperuser),
+		Options:     stringMapValue(curRole.Options),
 	}
 	for _, member := range curRole.MemberOf {
 		state.MemberOf = append(state.MemberOf, types.StringValue(member))
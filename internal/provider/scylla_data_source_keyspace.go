@@ -0,0 +1,227 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/i1snow/terraform-provider-scylladb/scylladb"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &keyspaceDataSource{}
+	_ datasource.DataSourceWithConfigure = &keyspaceDataSource{}
+)
+
+// NewKeyspaceDataSource is a helper function to simplify the provider implementation.
+func NewKeyspaceDataSource() datasource.DataSource {
+	return &keyspaceDataSource{}
+}
+
+// keyspaceDataSource is the data source implementation.
+type keyspaceDataSource struct {
+	client *scylladb.Cluster
+}
+
+// keyspaceDataSourceModel maps the data source schema data. It is also an
+// element of the keyspaces list of the scylladb_keyspaces data source.
+type keyspaceDataSourceModel struct {
+	Name              types.String   `tfsdk:"name"`
+	ReplicationClass  types.String   `tfsdk:"replication_class"`
+	ReplicationFactor types.Int64    `tfsdk:"replication_factor"`
+	DataCenters       types.Map      `tfsdk:"datacenters"`
+	DataCenterRacks   types.Map      `tfsdk:"datacenter_racks"`
+	DurableWrites     types.Bool     `tfsdk:"durable_writes"`
+	Tablets           types.Object   `tfsdk:"tablets"`
+	Tables            []types.String `tfsdk:"tables"`
+	Views             []types.String `tfsdk:"views"`
+	Types             []types.String `tfsdk:"types"`
+}
+
+// Metadata returns the data source type name.
+func (d *keyspaceDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_keyspace"
+}
+
+// Schema defines the schema for the data source.
+func (d *keyspaceDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads a keyspace, its replication and the names of its tables, materialized views and user defined types.",
+		Attributes: keyspaceDataSourceAttributes(schema.StringAttribute{
+			Required:    true,
+			Description: "The name of the keyspace to look up.",
+		}),
+	}
+}
+
+// keyspaceDataSourceAttributes returns the attributes of a keyspace, with
+// name as the attribute holding its name.
+func keyspaceDataSourceAttributes(name schema.StringAttribute) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"name": name,
+		"replication_class": schema.StringAttribute{
+			Computed:    true,
+			Description: "The short name of the replication class, such as `NetworkTopologyStrategy`.",
+		},
+		"replication_factor": schema.Int64Attribute{
+			Computed:    true,
+			Description: "The replication factor of a `SimpleStrategy` keyspace.",
+		},
+		"datacenters": schema.MapAttribute{
+			Computed:    true,
+			Description: "The replication factor of each data center of a `NetworkTopologyStrategy` keyspace.",
+			ElementType: types.Int64Type,
+		},
+		"datacenter_racks": schema.MapAttribute{
+			Computed:    true,
+			Description: "The racks of each data center of a `NetworkTopologyStrategy` keyspace that uses rack lists.",
+			ElementType: types.SetType{ElemType: types.StringType},
+		},
+		"durable_writes": schema.BoolAttribute{
+			Computed:    true,
+			Description: "Whether writes to the keyspace go through the commit log.",
+		},
+		"tablets": schema.SingleNestedAttribute{
+			Computed:    true,
+			Description: "The tablets configuration of the keyspace.",
+			Attributes: map[string]schema.Attribute{
+				"enabled": schema.BoolAttribute{
+					Computed:    true,
+					Description: "Whether the keyspace uses tablets rather than vnodes.",
+				},
+				"initial": schema.Int64Attribute{
+					Computed:    true,
+					Description: "The initial number of tablets of each table of the keyspace, `0` when the cluster decides or the keyspace uses vnodes.",
+				},
+			},
+		},
+		"tables": schema.ListAttribute{
+			Computed:    true,
+			Description: "The names of the tables of the keyspace, sorted.",
+			ElementType: types.StringType,
+		},
+		"views": schema.ListAttribute{
+			Computed:    true,
+			Description: "The names of the materialized views of the keyspace, sorted.",
+			ElementType: types.StringType,
+		},
+		"types": schema.ListAttribute{
+			Computed:    true,
+			Description: "The names of the user defined types of the keyspace, sorted.",
+			ElementType: types.StringType,
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *keyspaceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config keyspaceDataSourceModel
+
+	// Read config.
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := config.Name.ValueString()
+	state, err := readKeyspaceDataSource(ctx, d.client, name)
+	if errors.Is(err, scylladb.ErrKeyspaceNotFound) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"Keyspace not found",
+			fmt.Sprintf("The keyspace %q does not exist in the cluster.", name),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read the keyspace",
+			err.Error(),
+		)
+		return
+	}
+
+	// Set state.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *keyspaceDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*scylladb.Cluster)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *scylladb.Cluster, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// readKeyspaceDataSource reads a keyspace and the names of its tables, views
+// and types into the data source model.
+func readKeyspaceDataSource(ctx context.Context, client *scylladb.Cluster, name string) (keyspaceDataSourceModel, error) {
+	keyspace, err := client.GetKeyspace(name)
+	if err != nil {
+		return keyspaceDataSourceModel{}, err
+	}
+	tables, err := client.ListTables(name)
+	if err != nil {
+		return keyspaceDataSourceModel{}, fmt.Errorf("failed to list the tables of %s: %w", name, err)
+	}
+	views, err := client.ListViews(name)
+	if err != nil {
+		return keyspaceDataSourceModel{}, fmt.Errorf("failed to list the views of %s: %w", name, err)
+	}
+	userTypes, err := client.ListTypes(name)
+	if err != nil {
+		return keyspaceDataSourceModel{}, fmt.Errorf("failed to list the types of %s: %w", name, err)
+	}
+
+	model := keyspaceDataSourceModel{
+		Name:              types.StringValue(keyspace.Name),
+		ReplicationClass:  types.StringValue(keyspace.Replication.Class),
+		ReplicationFactor: types.Int64Null(),
+		DataCenters:       types.MapNull(types.Int64Type),
+		DataCenterRacks:   types.MapNull(types.SetType{ElemType: types.StringType}),
+		DurableWrites:     types.BoolValue(keyspace.DurableWrites),
+		Tablets:           tabletsValue(keyspace.Tablets),
+		Tables:            stringValues(tables),
+		Views:             stringValues(views),
+		Types:             stringValues(userTypes),
+	}
+	if keyspace.Replication.ReplicationFactor > 0 {
+		model.ReplicationFactor = types.Int64Value(int64(keyspace.Replication.ReplicationFactor))
+	}
+	if len(keyspace.Replication.DataCenters) > 0 {
+		model.DataCenters = dataCentersValue(keyspace.Replication.DataCenters)
+	}
+	if len(keyspace.Replication.Racks) > 0 {
+		var diags diag.Diagnostics
+		model.DataCenterRacks, diags = types.MapValueFrom(ctx, types.SetType{ElemType: types.StringType}, keyspace.Replication.Racks)
+		if diags.HasError() {
+			return keyspaceDataSourceModel{}, fmt.Errorf("failed to convert the racks of %s", name)
+		}
+	}
+	return model, nil
+}
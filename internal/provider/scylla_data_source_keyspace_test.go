@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/i1snow/terraform-provider-scylladb/internal/testutil"
+)
+
+func TestAccKeyspaceDataSource(t *testing.T) {
+	devClusterHost := testutil.NewTestContainer(t)
+	providerConfig := fmt.Sprintf(providerConfigFmt, devClusterHost)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					cluster := testAccClient(t, devClusterHost)
+					for _, query := range []string{
+						`CREATE KEYSPACE "catalog_ks" WITH replication = {'class': 'NetworkTopologyStrategy', 'datacenter1': '1'} AND tablets = {'enabled': 'false'}`,
+						`CREATE TYPE "catalog_ks"."price" (amount decimal, currency text)`,
+						`CREATE TABLE "catalog_ks"."items" (id int PRIMARY KEY, category text)`,
+						`CREATE MATERIALIZED VIEW "catalog_ks"."items_by_category" AS SELECT * FROM "catalog_ks"."items" WHERE category IS NOT NULL AND id IS NOT NULL PRIMARY KEY (category, id)`,
+					} {
+						if err := cluster.Session.Query(query).Exec(); err != nil {
+							t.Fatalf("failed to set up the keyspace: %s", err)
+						}
+					}
+				},
+				Config: providerConfig + `
+data "scylladb_keyspace" "catalog" {
+  name = "catalog_ks"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.scylladb_keyspace.catalog", "replication_class", "NetworkTopologyStrategy"),
+					resource.TestCheckResourceAttr("data.scylladb_keyspace.catalog", "datacenters.datacenter1", "1"),
+					resource.TestCheckNoResourceAttr("data.scylladb_keyspace.catalog", "replication_factor"),
+					resource.TestCheckResourceAttr("data.scylladb_keyspace.catalog", "durable_writes", "true"),
+					resource.TestCheckResourceAttr("data.scylladb_keyspace.catalog", "tablets.enabled", "false"),
+					resource.TestCheckResourceAttr("data.scylladb_keyspace.catalog", "tables.#", "1"),
+					resource.TestCheckResourceAttr("data.scylladb_keyspace.catalog", "tables.0", "items"),
+					resource.TestCheckResourceAttr("data.scylladb_keyspace.catalog", "views.#", "1"),
+					resource.TestCheckResourceAttr("data.scylladb_keyspace.catalog", "views.0", "items_by_category"),
+					resource.TestCheckResourceAttr("data.scylladb_keyspace.catalog", "types.#", "1"),
+					resource.TestCheckResourceAttr("data.scylladb_keyspace.catalog", "types.0", "price"),
+				),
+			},
+			{
+				Config: providerConfig + `
+data "scylladb_keyspace" "missing" {
+  name = "missing_ks"
+}
+`,
+				ExpectError: regexp.MustCompile("Keyspace not found"),
+			},
+		},
+	})
+}
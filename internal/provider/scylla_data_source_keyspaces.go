@@ -0,0 +1,142 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/i1snow/terraform-provider-scylladb/scylladb"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &keyspacesDataSource{}
+	_ datasource.DataSourceWithConfigure = &keyspacesDataSource{}
+)
+
+// NewKeyspacesDataSource is a helper function to simplify the provider implementation.
+func NewKeyspacesDataSource() datasource.DataSource {
+	return &keyspacesDataSource{}
+}
+
+// keyspacesDataSource is the data source implementation.
+type keyspacesDataSource struct {
+	client *scylladb.Cluster
+}
+
+// keyspacesDataSourceModel maps the data source schema data.
+type keyspacesDataSourceModel struct {
+	ExcludeSystem types.Bool                `tfsdk:"exclude_system"`
+	Names         []types.String            `tfsdk:"names"`
+	Keyspaces     []keyspaceDataSourceModel `tfsdk:"keyspaces"`
+}
+
+// Metadata returns the data source type name.
+func (d *keyspacesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_keyspaces"
+}
+
+// Schema defines the schema for the data source.
+func (d *keyspacesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the keyspaces of the cluster with their replication and the names of their tables, materialized views and user defined types.",
+		Attributes: map[string]schema.Attribute{
+			"exclude_system": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Leave out the keyspaces of the cluster itself, such as `system` and `system_schema`. Defaults to `false`.",
+			},
+			"names": schema.ListAttribute{
+				Computed:    true,
+				Description: "The names of the keyspaces, sorted.",
+				ElementType: types.StringType,
+			},
+			"keyspaces": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The keyspaces, sorted by name.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: keyspaceDataSourceAttributes(schema.StringAttribute{
+						Computed:    true,
+						Description: "The name of the keyspace.",
+					}),
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *keyspacesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config keyspacesDataSourceModel
+
+	// Read config.
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	names, err := d.client.ListKeyspaces()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list the keyspaces",
+			err.Error(),
+		)
+		return
+	}
+
+	// Map response body to model.
+	state := config
+	state.Names = []types.String{}
+	state.Keyspaces = []keyspaceDataSourceModel{}
+	for _, name := range names {
+		if config.ExcludeSystem.ValueBool() && scylladb.IsSystemKeyspace(name) {
+			continue
+		}
+		keyspace, err := readKeyspaceDataSource(ctx, d.client, name)
+		if errors.Is(err, scylladb.ErrKeyspaceNotFound) {
+			// Dropped while listing
+			continue
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to read the keyspace",
+				err.Error(),
+			)
+			return
+		}
+		state.Names = append(state.Names, types.StringValue(name))
+		state.Keyspaces = append(state.Keyspaces, keyspace)
+	}
+
+	// Set state.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *keyspacesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*scylladb.Cluster)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *scylladb.Cluster, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/i1snow/terraform-provider-scylladb/internal/testutil"
+)
+
+func TestAccKeyspacesDataSource(t *testing.T) {
+	devClusterHost := testutil.NewTestContainer(t)
+	providerConfig := fmt.Sprintf(providerConfigFmt, devClusterHost)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "scylladb_keyspace" "app" {
+  name                = "app_ks"
+  deletion_protection = false
+  datacenters = {
+    datacenter1 = 1
+  }
+}
+
+data "scylladb_keyspaces" "user" {
+  exclude_system = true
+  depends_on     = [scylladb_keyspace.app]
+}
+
+data "scylladb_keyspaces" "all" {
+  depends_on = [scylladb_keyspace.app]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.scylladb_keyspaces.user", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.scylladb_keyspaces.user", "names.0", "app_ks"),
+					resource.TestCheckResourceAttr("data.scylladb_keyspaces.user", "keyspaces.0.name", "app_ks"),
+					resource.TestCheckResourceAttr("data.scylladb_keyspaces.user", "keyspaces.0.datacenters.datacenter1", "1"),
+					resource.TestCheckResourceAttr("data.scylladb_keyspaces.user", "keyspaces.0.tables.#", "0"),
+					resource.TestCheckTypeSetElemAttr("data.scylladb_keyspaces.all", "names.*", "system_schema"),
+					resource.TestCheckTypeSetElemAttr("data.scylladb_keyspaces.all", "names.*", "app_ks"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,189 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/i1snow/terraform-provider-scylladb/scylladb"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &permissionsDataSource{}
+	_ datasource.DataSourceWithConfigure = &permissionsDataSource{}
+)
+
+func NewPermissionsDataSource() datasource.DataSource {
+	return &permissionsDataSource{}
+}
+
+// permissionsDataSource is the data source implementation.
+type permissionsDataSource struct {
+	client *scylladb.Cluster
+}
+
+// permissionsDataSourceModel maps the data source schema data.
+type permissionsDataSourceModel struct {
+	Role             types.String                 `tfsdk:"role"`
+	IncludeInherited types.Bool                   `tfsdk:"include_inherited"`
+	Permissions      []permissionsPermissionModel `tfsdk:"permissions"`
+}
+
+// permissionsPermissionModel maps a single permission of the permissions list.
+type permissionsPermissionModel struct {
+	Role              types.String   `tfsdk:"role"`
+	Inherited         types.Bool     `tfsdk:"inherited"`
+	Permission        types.String   `tfsdk:"permission"`
+	ResourceType      types.String   `tfsdk:"resource_type"`
+	Keyspace          types.String   `tfsdk:"keyspace"`
+	Table             types.String   `tfsdk:"table"`
+	TargetRole        types.String   `tfsdk:"target_role"`
+	Function          types.String   `tfsdk:"function"`
+	FunctionArguments []types.String `tfsdk:"function_arguments"`
+}
+
+// Metadata returns the data source type name.
+func (d *permissionsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_permissions"
+}
+
+// Schema defines the schema for the data source.
+func (d *permissionsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the permissions of a role, optionally including the permissions it inherits from the roles granted to it.",
+		Attributes: map[string]schema.Attribute{
+			"role": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the role to look up.",
+			},
+			"include_inherited": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Also return the permissions the role inherits from the roles granted to it. Defaults to `false`.",
+			},
+			"permissions": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The permissions of the role, sorted by holder, resource and permission.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"role": schema.StringAttribute{
+							Computed:    true,
+							Description: "The role the permission was granted to.",
+						},
+						"inherited": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the permission is inherited from another role.",
+						},
+						"permission": schema.StringAttribute{
+							Computed:    true,
+							Description: "The permission.",
+						},
+						"resource_type": schema.StringAttribute{
+							Computed:    true,
+							Description: "The type of the resource.",
+						},
+						"keyspace": schema.StringAttribute{
+							Computed:    true,
+							Description: "The keyspace of the resource, if any.",
+						},
+						"table": schema.StringAttribute{
+							Computed:    true,
+							Description: "The table of a `table` resource.",
+						},
+						"target_role": schema.StringAttribute{
+							Computed:    true,
+							Description: "The role of a `role` resource.",
+						},
+						"function": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the function of a `function` resource.",
+						},
+						"function_arguments": schema.ListAttribute{
+							Computed:    true,
+							Description: "The CQL types of the arguments of the function of a `function` resource.",
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *permissionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config permissionsDataSourceModel
+
+	// Read config.
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	role := config.Role.ValueString()
+	permissions, err := d.client.ListPermissions(role, config.IncludeInherited.ValueBool())
+	if errors.Is(err, scylladb.ErrRoleNotFound) {
+		resp.Diagnostics.AddError(
+			"Role not found",
+			fmt.Sprintf("The role %q does not exist in the cluster.", role),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list the permissions of the role",
+			err.Error(),
+		)
+		return
+	}
+
+	// Map response body to model.
+	state := config
+	state.Permissions = []permissionsPermissionModel{}
+	for _, p := range permissions {
+		state.Permissions = append(state.Permissions, permissionsPermissionModel{
+			Role:              types.StringValue(p.Role),
+			Inherited:         types.BoolValue(p.Role != role),
+			Permission:        types.StringValue(p.Permission),
+			ResourceType:      types.StringValue(string(p.Resource.Type)),
+			Keyspace:          optionalStringValue(p.Resource.Keyspace),
+			Table:             optionalStringValue(p.Resource.Table),
+			TargetRole:        optionalStringValue(p.Resource.Role),
+			Function:          optionalStringValue(p.Resource.Function),
+			FunctionArguments: stringValues(p.Resource.FunctionArguments),
+		})
+	}
+
+	// Set state.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *permissionsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*scylladb.Cluster)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *scylladb.Cluster, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/i1snow/terraform-provider-scylladb/internal/testutil"
+)
+
+func TestAccPermissionsDataSource(t *testing.T) {
+	devClusterHost := testutil.NewTestContainer(t)
+	providerConfig := fmt.Sprintf(providerConfigFmt, devClusterHost)
+	permissionsConfig := providerConfig + `
+resource "scylladb_role" "reader" {
+  role = "reader"
+}
+
+resource "scylladb_role" "app" {
+  role      = "app"
+  member_of = [scylladb_role.reader.role]
+}
+
+resource "scylladb_grant" "reader_describe" {
+  role          = scylladb_role.reader.role
+  permission    = "DESCRIBE"
+  resource_type = "all_roles"
+}
+
+resource "scylladb_grant" "app_alter" {
+  role          = scylladb_role.app.role
+  permission    = "ALTER"
+  resource_type = "role"
+  target_role   = scylladb_role.reader.role
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: permissionsConfig + `
+data "scylladb_permissions" "direct" {
+  role       = scylladb_role.app.role
+  depends_on = [scylladb_grant.reader_describe, scylladb_grant.app_alter]
+}
+
+data "scylladb_permissions" "effective" {
+  role              = scylladb_role.app.role
+  include_inherited = true
+  depends_on        = [scylladb_grant.reader_describe, scylladb_grant.app_alter]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.scylladb_permissions.direct", "permissions.#", "1"),
+					resource.TestCheckResourceAttr("data.scylladb_permissions.direct", "permissions.0.role", "app"),
+					resource.TestCheckResourceAttr("data.scylladb_permissions.direct", "permissions.0.inherited", "false"),
+					resource.TestCheckResourceAttr("data.scylladb_permissions.direct", "permissions.0.permission", "ALTER"),
+					resource.TestCheckResourceAttr("data.scylladb_permissions.direct", "permissions.0.resource_type", "role"),
+					resource.TestCheckResourceAttr("data.scylladb_permissions.direct", "permissions.0.target_role", "reader"),
+					resource.TestCheckResourceAttr("data.scylladb_permissions.effective", "permissions.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.scylladb_permissions.effective", "permissions.*", map[string]string{
+						"role":          "reader",
+						"inherited":     "true",
+						"permission":    "DESCRIBE",
+						"resource_type": "all_roles",
+					}),
+				),
+			},
+			{
+				Config: providerConfig + `
+data "scylladb_permissions" "missing" {
+  role = "missing"
+}
+`,
+				ExpectError: regexp.MustCompile("Role not found"),
+			},
+		},
+	})
+}
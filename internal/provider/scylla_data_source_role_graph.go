@@ -0,0 +1,178 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/i1snow/terraform-provider-scylladb/scylladb"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &roleGraphDataSource{}
+	_ datasource.DataSourceWithConfigure = &roleGraphDataSource{}
+)
+
+// NewRoleGraphDataSource is a helper function to simplify the provider implementation.
+func NewRoleGraphDataSource() datasource.DataSource {
+	return &roleGraphDataSource{}
+}
+
+// roleGraphDataSource is the data source implementation.
+type roleGraphDataSource struct {
+	client *scylladb.Cluster
+}
+
+// roleGraphDataSourceModel maps the data source schema data.
+type roleGraphDataSourceModel struct {
+	Role              types.String   `tfsdk:"role"`
+	ExportJSON        types.Bool     `tfsdk:"export_json"`
+	DirectMembers     []types.String `tfsdk:"direct_members"`
+	TransitiveMembers []types.String `tfsdk:"transitive_members"`
+	DirectParents     []types.String `tfsdk:"direct_parents"`
+	TransitiveParents []types.String `tfsdk:"transitive_parents"`
+	JSON              types.String   `tfsdk:"json"`
+}
+
+// Metadata returns the data source type name.
+func (d *roleGraphDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_graph"
+}
+
+// Schema defines the schema for the data source.
+func (d *roleGraphDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resolves the role membership graph around a role, including memberships inherited through other roles.",
+		Attributes: map[string]schema.Attribute{
+			"role": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the role to resolve.",
+			},
+			"export_json": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to export the membership graph of every role of the cluster in `json`.",
+			},
+			"direct_members": schema.SetAttribute{
+				Computed:    true,
+				Description: "The roles that were granted the role.",
+				ElementType: types.StringType,
+			},
+			"transitive_members": schema.SetAttribute{
+				Computed:    true,
+				Description: "The roles that inherit the role, directly or through other roles.",
+				ElementType: types.StringType,
+			},
+			"direct_parents": schema.SetAttribute{
+				Computed:    true,
+				Description: "The roles that were granted to the role.",
+				ElementType: types.StringType,
+			},
+			"transitive_parents": schema.SetAttribute{
+				Computed:    true,
+				Description: "The roles that the role holds, directly or through other roles.",
+				ElementType: types.StringType,
+			},
+			"json": schema.StringAttribute{
+				Computed:    true,
+				Description: "The membership graph of every role as a JSON object keyed by role name, with the `member_of` and `members` of each role. Only set when `export_json` is true.",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *roleGraphDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config roleGraphDataSourceModel
+
+	// Read config.
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	graph, err := d.client.GetRoleGraph()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read the role graph",
+			err.Error(),
+		)
+		return
+	}
+
+	role := config.Role.ValueString()
+	if !graph.HasRole(role) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("role"),
+			"Role not found",
+			fmt.Sprintf("The role %q does not exist in the cluster.", role),
+		)
+		return
+	}
+
+	// Map response body to model.
+	state := roleGraphDataSourceModel{
+		Role:              config.Role,
+		ExportJSON:        config.ExportJSON,
+		DirectMembers:     stringValues(graph.DirectMembers(role)),
+		TransitiveMembers: stringValues(graph.TransitiveMembers(role)),
+		DirectParents:     stringValues(graph.DirectParents(role)),
+		TransitiveParents: stringValues(graph.TransitiveParents(role)),
+		JSON:              types.StringNull(),
+	}
+	if config.ExportJSON.ValueBool() {
+		b, err := json.Marshal(graph)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to export the role graph",
+				err.Error(),
+			)
+			return
+		}
+		state.JSON = types.StringValue(string(b))
+	}
+
+	// Set state.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *roleGraphDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*scylladb.Cluster)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *scylladb.Cluster, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// stringValues converts a Go slice into the elements of a list or set
+// attribute. An empty slice becomes an empty, not null, collection.
+func stringValues(values []string) []types.String {
+	out := make([]types.String, 0, len(values))
+	for _, v := range values {
+		out = append(out, types.StringValue(v))
+	}
+	return out
+}
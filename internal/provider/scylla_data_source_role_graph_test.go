@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/i1snow/terraform-provider-scylladb/internal/testutil"
+)
+
+func TestAccRoleGraphDataSource(t *testing.T) {
+	devClusterHost := testutil.NewTestContainer(t)
+	providerConfig := fmt.Sprintf(providerConfigFmt, devClusterHost)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "scylladb_role" "reader" {
+  role = "reader"
+}
+
+resource "scylladb_role" "writer" {
+  role      = "writer"
+  member_of = [scylladb_role.reader.role]
+}
+
+resource "scylladb_role" "app" {
+  role      = "app"
+  can_login = true
+  member_of = [scylladb_role.writer.role]
+}
+
+data "scylladb_role_graph" "reader" {
+  role        = scylladb_role.reader.role
+  export_json = true
+  depends_on  = [scylladb_role.app]
+}
+
+data "scylladb_role_graph" "app" {
+  role       = scylladb_role.app.role
+  depends_on = [scylladb_role.app]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.scylladb_role_graph.reader", "direct_members.#", "1"),
+					resource.TestCheckTypeSetElemAttr("data.scylladb_role_graph.reader", "direct_members.*", "writer"),
+					resource.TestCheckResourceAttr("data.scylladb_role_graph.reader", "transitive_members.#", "2"),
+					resource.TestCheckTypeSetElemAttr("data.scylladb_role_graph.reader", "transitive_members.*", "app"),
+					resource.TestCheckResourceAttr("data.scylladb_role_graph.reader", "transitive_parents.#", "0"),
+					resource.TestCheckResourceAttrSet("data.scylladb_role_graph.reader", "json"),
+					resource.TestCheckResourceAttr("data.scylladb_role_graph.app", "direct_parents.#", "1"),
+					resource.TestCheckResourceAttr("data.scylladb_role_graph.app", "transitive_parents.#", "2"),
+					resource.TestCheckTypeSetElemAttr("data.scylladb_role_graph.app", "transitive_parents.*", "reader"),
+					resource.TestCheckNoResourceAttr("data.scylladb_role_graph.app", "json"),
+				),
+			},
+		},
+	})
+}
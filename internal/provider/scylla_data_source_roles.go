@@ -0,0 +1,194 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/i1snow/terraform-provider-scylladb/scylladb"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &rolesDataSource{}
+	_ datasource.DataSourceWithConfigure = &rolesDataSource{}
+)
+
+// NewRolesDataSource is a helper function to simplify the provider implementation.
+func NewRolesDataSource() datasource.DataSource {
+	return &rolesDataSource{}
+}
+
+// rolesDataSource is the data source implementation.
+type rolesDataSource struct {
+	client *scylladb.Cluster
+}
+
+// rolesDataSourceModel maps the data source schema data.
+type rolesDataSourceModel struct {
+	CanLogin    types.Bool       `tfsdk:"can_login"`
+	IsSuperuser types.Bool       `tfsdk:"is_superuser"`
+	MemberOf    types.String     `tfsdk:"member_of"`
+	NameRegex   types.String     `tfsdk:"name_regex"`
+	Roles       []rolesRoleModel `tfsdk:"roles"`
+}
+
+// rolesRoleModel maps a single role of the roles list.
+type rolesRoleModel struct {
+	Role        types.String            `tfsdk:"role"`
+	CanLogin    types.Bool              `tfsdk:"can_login"`
+	IsSuperuser types.Bool              `tfsdk:"is_superuser"`
+	MemberOf    []types.String          `tfsdk:"member_of"`
+	Options     map[string]types.String `tfsdk:"options"`
+}
+
+// Metadata returns the data source type name.
+func (d *rolesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_roles"
+}
+
+// Schema defines the schema for the data source.
+func (d *rolesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the roles of the cluster, optionally filtered by their attributes.",
+		Attributes: map[string]schema.Attribute{
+			"can_login": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only return roles whose can_login matches this value.",
+			},
+			"is_superuser": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only return roles whose is_superuser matches this value.",
+			},
+			"member_of": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return roles that were directly granted this role.",
+			},
+			"name_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return roles whose name matches this regular expression.",
+			},
+			"roles": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The matching roles, sorted by name.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"role": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the role",
+						},
+						"can_login": schema.BoolAttribute{
+							Computed:    true,
+							Description: "whether a user can login as a role",
+						},
+						"is_superuser": schema.BoolAttribute{
+							Computed:    true,
+							Description: "whether the role is a superuser",
+						},
+						"member_of": schema.SetAttribute{
+							Computed:    true,
+							Description: "a set of roles granted to the role",
+							ElementType: types.StringType,
+						},
+						"options": schema.MapAttribute{
+							Computed:    true,
+							Description: "custom options of the role, when reported by the role manager",
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *rolesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config rolesDataSourceModel
+
+	// Read config.
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := scylladb.RoleFilter{
+		CanLogin:    config.CanLogin.ValueBoolPointer(),
+		IsSuperuser: config.IsSuperuser.ValueBoolPointer(),
+		MemberOf:    config.MemberOf.ValueString(),
+	}
+	if !config.NameRegex.IsNull() {
+		nameRegex, err := regexp.Compile(config.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name_regex"),
+				"Invalid name_regex",
+				err.Error(),
+			)
+			return
+		}
+		filter.NameRegex = nameRegex
+	}
+
+	roles, err := d.client.ListRoles()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list the roles",
+			err.Error(),
+		)
+		return
+	}
+
+	// Map response body to model.
+	state := config
+	state.Roles = []rolesRoleModel{}
+	for _, role := range roles {
+		if !filter.Match(role) {
+			continue
+		}
+		item := rolesRoleModel{
+			Role:        types.StringValue(role.Role),
+			CanLogin:    types.BoolValue(role.CanLogin),
+			IsSuperuser: types.BoolValue(role.IsSuperuser),
+			Options:     stringMapValue(role.Options),
+		}
+		for _, member := range role.MemberOf {
+			item.MemberOf = append(item.MemberOf, types.StringValue(member))
+		}
+		state.Roles = append(state.Roles, item)
+	}
+
+	// Set state.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *rolesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*scylladb.Cluster)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *scylladb.Cluster, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
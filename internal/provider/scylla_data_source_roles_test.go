@@ -0,0 +1,72 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/i1snow/terraform-provider-scylladb/internal/testutil"
+)
+
+func TestAccRolesDataSource(t *testing.T) {
+	devClusterHost := testutil.NewTestContainer(t)
+	providerConfig := fmt.Sprintf(providerConfigFmt, devClusterHost)
+	rolesConfig := providerConfig + `
+resource "scylladb_role" "reader" {
+  role = "reader"
+}
+
+resource "scylladb_role" "app_reader" {
+  role      = "app-reader"
+  can_login = true
+  member_of = [scylladb_role.reader.role]
+}
+
+resource "scylladb_role" "app_writer" {
+  role      = "app-writer"
+  can_login = true
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: rolesConfig + `
+data "scylladb_roles" "all" {
+  depends_on = [scylladb_role.reader, scylladb_role.app_reader, scylladb_role.app_writer]
+}
+
+data "scylladb_roles" "apps" {
+  name_regex = "^app-"
+  can_login  = true
+  depends_on = [scylladb_role.app_reader, scylladb_role.app_writer]
+}
+
+data "scylladb_roles" "readers" {
+  member_of  = scylladb_role.reader.role
+  depends_on = [scylladb_role.app_reader]
+}
+
+data "scylladb_roles" "superusers" {
+  is_superuser = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.scylladb_roles.all", "roles.#", "4"),
+					resource.TestCheckResourceAttr("data.scylladb_roles.apps", "roles.#", "2"),
+					resource.TestCheckResourceAttr("data.scylladb_roles.apps", "roles.0.role", "app-reader"),
+					resource.TestCheckResourceAttr("data.scylladb_roles.apps", "roles.1.role", "app-writer"),
+					resource.TestCheckResourceAttr("data.scylladb_roles.readers", "roles.#", "1"),
+					resource.TestCheckResourceAttr("data.scylladb_roles.readers", "roles.0.role", "app-reader"),
+					resource.TestCheckTypeSetElemAttr("data.scylladb_roles.readers", "roles.0.member_of.*", "reader"),
+					resource.TestCheckResourceAttr("data.scylladb_roles.superusers", "roles.#", "1"),
+					resource.TestCheckResourceAttr("data.scylladb_roles.superusers", "roles.0.role", "cassandra"),
+				),
+			},
+		},
+	})
+}
@@ -29,6 +29,8 @@ data "scylladb_role" "cassandra" {
 					resource.TestCheckResourceAttr("data.scylladb_role.cassandra", "role", "cassandra"),
 					resource.TestCheckResourceAttr("data.scylladb_role.cassandra", "can_login", "true"),
 					resource.TestCheckResourceAttr("data.scylladb_role.cassandra", "is_superuser", "true"),
+					// The built-in role manager does not report custom options
+					resource.TestCheckNoResourceAttr("data.scylladb_role.cassandra", "options.%"),
 				),
 			},
 		},
@@ -0,0 +1,280 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/i1snow/terraform-provider-scylladb/scylladb"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &defaultSuperuserLockdownResource{}
+var _ resource.ResourceWithConfigure = &defaultSuperuserLockdownResource{}
+
+func NewDefaultSuperuserLockdownResource() resource.Resource {
+	return &defaultSuperuserLockdownResource{}
+}
+
+// defaultSuperuserLockdownResource defines the resource implementation.
+type defaultSuperuserLockdownResource struct {
+	client *scylladb.Cluster
+}
+
+// defaultSuperuserLockdownResourceModel maps the resource source schema data.
+type defaultSuperuserLockdownResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	DefaultRole types.String `tfsdk:"default_role"`
+	Superuser   types.String `tfsdk:"superuser"`
+	// SuperuserPasswordWO is write-only and therefore always null in the
+	// plan and state.
+	SuperuserPasswordWO        types.String `tfsdk:"superuser_password_wo"`
+	SuperuserPasswordWOVersion types.Int64  `tfsdk:"superuser_password_wo_version"`
+	DropDefaultRole            types.Bool   `tfsdk:"drop_default_role"`
+}
+
+// Metadata returns the resource type name.
+func (r *defaultSuperuserLockdownResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_default_superuser_lockdown"
+}
+
+// The resource uses the `Schema` method to define the supported configuration, plan, and state attribute names and types.
+func (r *defaultSuperuserLockdownResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Hardens the default `cassandra` superuser. A replacement superuser is created, " +
+			"checked to be able to log in with a new session, and only then the default role loses its login or is dropped. " +
+			"Destroying the resource gives the login back to the default role, unless it was dropped, and keeps the replacement superuser. " +
+			"Switch the provider credentials to the replacement superuser once it is applied.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The name of the default role.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"default_role": schema.StringAttribute{
+				Description: "The default superuser to lock down. Defaults to `cassandra`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(scylladb.DefaultSuperuser),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"superuser": schema.StringAttribute{
+				Description: "The name of the superuser that replaces the default role. It is created when missing.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"superuser_password_wo": schema.StringAttribute{
+				Description: "The password of the replacement superuser. This value is write-only and is never stored in the state. Requires Terraform 1.11 or later.",
+				Required:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+			},
+			"superuser_password_wo_version": schema.Int64Attribute{
+				Description: "The version of `superuser_password_wo`. Change this value to update the password of the replacement superuser.",
+				Optional:    true,
+			},
+			"drop_default_role": schema.BoolAttribute{
+				Description: "Drop the default role instead of disabling its login. A dropped role is not recreated on destroy. Defaults to `false`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+// Resources use the optional `Configure` method to fetch configured clients from the provider.
+func (r *defaultSuperuserLockdownResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*scylladb.Cluster)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *scylladb.Cluster, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// The provider uses the `Create` method to create a new resource based on the schemadata.
+func (r *defaultSuperuserLockdownResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan defaultSuperuserLockdownResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Write-only attributes are only available in the configuration
+	var password types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("superuser_password_wo"), &password)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.lockDown(ctx, plan, password.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Populate computed attribute values
+	plan.ID = plan.DefaultRole
+
+	// Set state to fully populate data
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// The provider uses the `Read` method to retrieve the resource's information and update the state
+// The provider invokes this function before every plan.
+func (r *defaultSuperuserLockdownResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state defaultSuperuserLockdownResourceModel
+
+	// Read state.
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	locked, err := r.client.IsLockedDown(lockdownFromModel(state))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read the lockdown of the default superuser",
+			err.Error(),
+		)
+		return
+	}
+	if !locked {
+		// The lockdown was undone outside of Terraform, so let Terraform apply it again
+		tflog.Warn(ctx, "Default superuser is no longer locked down, removing the lockdown from the state", map[string]any{
+			"default_role": state.DefaultRole.ValueString(),
+			"superuser":    state.Superuser.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Set state.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// The provider uses the `Update` method to apply a new password to the
+// replacement superuser. Running the lockdown again is harmless.
+func (r *defaultSuperuserLockdownResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan defaultSuperuserLockdownResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Write-only attributes are only available in the configuration
+	var password types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("superuser_password_wo"), &password)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.lockDown(ctx, plan, password.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// The provider uses the `Delete` method to give the login back to the default role.
+func (r *defaultSuperuserLockdownResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state defaultSuperuserLockdownResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Unlocking the default superuser", map[string]any{"default_role": state.DefaultRole.ValueString()})
+	err := r.client.Unlock(lockdownFromModel(state))
+	if errors.Is(err, scylladb.ErrRoleNotFound) {
+		resp.Diagnostics.AddWarning(
+			"Default superuser not restored",
+			fmt.Sprintf("The role %q was dropped and cannot be restored by Terraform.", state.DefaultRole.ValueString()),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to unlock the default superuser",
+			err.Error(),
+		)
+		return
+	}
+}
+
+// lockDown applies the lockdown of the plan with the password of the
+// replacement superuser.
+func (r *defaultSuperuserLockdownResource) lockDown(ctx context.Context, plan defaultSuperuserLockdownResourceModel, password string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	lockdown := lockdownFromModel(plan)
+	lockdown.Password = password
+
+	tflog.Debug(ctx, "Locking down the default superuser", map[string]any{
+		"default_role": lockdown.DefaultRole,
+		"superuser":    lockdown.Superuser,
+		"drop":         lockdown.Drop,
+	})
+	if err := r.client.LockDown(lockdown); err != nil {
+		diags.AddError(
+			"Unable to lock down the default superuser",
+			err.Error(),
+		)
+	}
+	return diags
+}
+
+// lockdownFromModel converts the resource model into a scylladb.Lockdown
+// without its password.
+func lockdownFromModel(model defaultSuperuserLockdownResourceModel) scylladb.Lockdown {
+	return scylladb.Lockdown{
+		DefaultRole: model.DefaultRole.ValueString(),
+		Superuser:   model.Superuser.ValueString(),
+		Drop:        model.DropDefaultRole.ValueBool(),
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/i1snow/terraform-provider-scylladb/internal/testutil"
+	"github.com/i1snow/terraform-provider-scylladb/scylladb"
+)
+
+func TestAccDefaultSuperuserLockdownResource(t *testing.T) {
+	devClusterHost := testutil.NewTestContainer(t)
+	// Every Terraform command reconnects, so the provider authenticates as
+	// the replacement superuser rather than the role it locks down.
+	adminProviderConfig := fmt.Sprintf(`
+provider "scylladb" {
+  host = "%s"
+  system_auth_keyspace = "system"
+  auth_login_userpass {
+    username = "admin"
+    password = "admin-secret"
+  }
+}
+`, devClusterHost)
+	lockdownConfig := adminProviderConfig + `
+resource "scylladb_default_superuser_lockdown" "this" {
+  superuser                     = "admin"
+  superuser_password_wo         = "admin-secret"
+  superuser_password_wo_version = 1
+}
+`
+	cluster := testAccClient(t, devClusterHost)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					err := cluster.CreateRole(scylladb.Role{Role: "admin", CanLogin: true, IsSuperuser: true, Password: "admin-secret"})
+					if err != nil {
+						t.Fatalf("failed to create the role: %s", err)
+					}
+				},
+				Config: lockdownConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("scylladb_default_superuser_lockdown.this", "id", "cassandra"),
+					resource.TestCheckResourceAttr("scylladb_default_superuser_lockdown.this", "drop_default_role", "false"),
+					resource.TestCheckNoResourceAttr("scylladb_default_superuser_lockdown.this", "superuser_password_wo"),
+					func(_ *terraform.State) error {
+						if err := cluster.VerifyLogin("cassandra", "cassandra"); err == nil {
+							return fmt.Errorf("the default superuser can still log in")
+						}
+						return nil
+					},
+				),
+			},
+			// Giving the login back outside of Terraform is undone
+			{
+				PreConfig: func() {
+					if err := cluster.Unlock(scylladb.Lockdown{DefaultRole: "cassandra"}); err != nil {
+						t.Fatalf("failed to unlock the role: %s", err)
+					}
+				},
+				Config:             lockdownConfig,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: lockdownConfig,
+			},
+			// Destroying the lockdown gives the login back to the default superuser
+			{
+				Config: adminProviderConfig,
+				Check: func(_ *terraform.State) error {
+					return cluster.VerifyLogin("cassandra", "cassandra")
+				},
+			},
+		},
+	})
+}
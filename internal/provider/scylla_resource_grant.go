@@ -0,0 +1,387 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/i1snow/terraform-provider-scylladb/scylladb"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &grantResource{}
+var _ resource.ResourceWithConfigure = &grantResource{}
+var _ resource.ResourceWithValidateConfig = &grantResource{}
+
+func NewGrantResource() resource.Resource {
+	return &grantResource{}
+}
+
+// grantResource defines the resource implementation.
+type grantResource struct {
+	client *scylladb.Cluster
+}
+
+// grantResourceModel maps the resource source schema data.
+type grantResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	Role              types.String `tfsdk:"role"`
+	Permission        types.String `tfsdk:"permission"`
+	ResourceType      types.String `tfsdk:"resource_type"`
+	Keyspace          types.String `tfsdk:"keyspace"`
+	Table             types.String `tfsdk:"table"`
+	TargetRole        types.String `tfsdk:"target_role"`
+	Function          types.String `tfsdk:"function"`
+	FunctionArguments types.List   `tfsdk:"function_arguments"`
+}
+
+// Metadata returns the resource type name.
+func (r *grantResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_grant"
+}
+
+// The resource uses the `Schema` method to define the supported configuration, plan, and state attribute names and types.
+func (r *grantResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Grants a single permission on a resource to a role. Every change replaces the grant.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The role, the permission and the resource separated by `|`.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"role": schema.StringAttribute{
+				Description: "The name of the role that receives the permission",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"permission": schema.StringAttribute{
+				Description: "The permission to grant, one of " + markdownList(scylladb.Permissions) + ".",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(scylladb.Permissions...),
+				},
+			},
+		},
+	}
+	for name, attribute := range permissionResourceAttributes(true) {
+		resp.Schema.Attributes[name] = attribute
+	}
+}
+
+// permissionResourceAttributes returns the attributes that identify the
+// resource of a permission. When requiresReplace is set, each of them forces
+// a replacement.
+func permissionResourceAttributes(requiresReplace bool) map[string]schema.Attribute {
+	resourceTypes := make([]string, 0, len(scylladb.ResourceTypes))
+	for _, t := range scylladb.ResourceTypes {
+		resourceTypes = append(resourceTypes, string(t))
+	}
+	var stringModifiers []planmodifier.String
+	var listModifiers []planmodifier.List
+	if requiresReplace {
+		stringModifiers = []planmodifier.String{stringplanmodifier.RequiresReplace()}
+		listModifiers = []planmodifier.List{listplanmodifier.RequiresReplace()}
+	}
+	return map[string]schema.Attribute{
+		"resource_type": schema.StringAttribute{
+			Description:   "The type of the resource, one of " + markdownList(resourceTypes) + ".",
+			Required:      true,
+			PlanModifiers: stringModifiers,
+			Validators: []validator.String{
+				stringvalidator.OneOf(resourceTypes...),
+			},
+		},
+		"keyspace": schema.StringAttribute{
+			Description:   "The keyspace of a `keyspace`, `table` or `function` resource, or the keyspace to restrict an `all_functions` resource to.",
+			Optional:      true,
+			PlanModifiers: stringModifiers,
+		},
+		"table": schema.StringAttribute{
+			Description:   "The table of a `table` resource.",
+			Optional:      true,
+			PlanModifiers: stringModifiers,
+		},
+		"target_role": schema.StringAttribute{
+			Description:   "The role of a `role` resource.",
+			Optional:      true,
+			PlanModifiers: stringModifiers,
+		},
+		"function": schema.StringAttribute{
+			Description:   "The name of the function of a `function` resource.",
+			Optional:      true,
+			PlanModifiers: stringModifiers,
+		},
+		"function_arguments": schema.ListAttribute{
+			Description:   "The CQL types of the arguments of the function of a `function` resource.",
+			Optional:      true,
+			ElementType:   types.StringType,
+			PlanModifiers: listModifiers,
+		},
+	}
+}
+
+// Resources use the optional `Configure` method to fetch configured clients from the provider.
+func (r *grantResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*scylladb.Cluster)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *scylladb.Cluster, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// The provider uses the `ValidateConfig` method to check at plan time that
+// the permission applies to the resource type.
+func (r *grantResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config grantResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validatePermissionConfig(ctx, path.Empty(), config.Permission, config.ResourceType, config.Keyspace,
+		config.Table, config.TargetRole, config.Function, config.FunctionArguments)...)
+}
+
+// The provider uses the `Create` method to create a new resource based on the schemadata.
+func (r *grantResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan grantResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	permission, diags := grantToPermission(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Grant the permission
+	tflog.Debug(ctx, "Granting permission", permissionLogFields(permission))
+	err := r.client.GrantPermission(permission)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to grant the permission",
+			err.Error(),
+		)
+		return
+	}
+
+	// Populate computed attribute values
+	plan.ID = types.StringValue(grantID(permission))
+
+	// Set state to fully populate data
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// The provider uses the `Read` method to retrieve the resource's information and update the state
+// The provider invokes this function before every plan.
+func (r *grantResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state grantResourceModel
+
+	// Read state.
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	permission, diags := grantToPermission(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	granted, err := r.client.HasPermission(permission)
+	if err != nil && !errors.Is(err, scylladb.ErrRoleNotFound) {
+		resp.Diagnostics.AddError(
+			"Unable to read the permission",
+			err.Error(),
+		)
+		return
+	}
+	if !granted {
+		// The permission was revoked outside of Terraform, so let Terraform grant it again
+		tflog.Warn(ctx, "Permission not found, removing it from the state", map[string]any{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Set state.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// A grant has no updatable attributes, every change replaces it.
+func (r *grantResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan grantResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// The provider uses the `Delete` method to attempt to retrieve the values from state and delete the resource.
+func (r *grantResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state grantResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	permission, diags := grantToPermission(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Nothing to revoke when the permission or the role is already gone
+	granted, err := r.client.HasPermission(permission)
+	if errors.Is(err, scylladb.ErrRoleNotFound) || (err == nil && !granted) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read the permission",
+			err.Error(),
+		)
+		return
+	}
+
+	// Revoke the permission
+	tflog.Debug(ctx, "Revoking permission", permissionLogFields(permission))
+	err = r.client.RevokePermission(permission)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to revoke the permission",
+			err.Error(),
+		)
+		return
+	}
+}
+
+// grantToPermission converts the grant model into a scylladb.Permission.
+func grantToPermission(ctx context.Context, model grantResourceModel) (scylladb.Permission, diag.Diagnostics) {
+	target, diags := permissionResource(ctx, model.ResourceType, model.Keyspace, model.Table,
+		model.TargetRole, model.Function, model.FunctionArguments)
+	return scylladb.Permission{
+		Role:       model.Role.ValueString(),
+		Permission: model.Permission.ValueString(),
+		Resource:   target,
+	}, diags
+}
+
+// permissionResource converts the resource attributes of a permission into
+// a scylladb.Resource.
+func permissionResource(ctx context.Context, resourceType, keyspace, table, targetRole, function types.String, functionArguments types.List) (scylladb.Resource, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	target := scylladb.Resource{
+		Type:     scylladb.ResourceType(resourceType.ValueString()),
+		Keyspace: keyspace.ValueString(),
+		Table:    table.ValueString(),
+		Role:     targetRole.ValueString(),
+		Function: function.ValueString(),
+	}
+	if !functionArguments.IsNull() && !functionArguments.IsUnknown() {
+		diags.Append(functionArguments.ElementsAs(ctx, &target.FunctionArguments, false)...)
+	}
+	return target, diags
+}
+
+// validatePermissionConfig checks the permission and resource attributes
+// found under base. Unknown values are skipped since they are only known
+// at apply time.
+func validatePermissionConfig(ctx context.Context, base path.Path, permission, resourceType, keyspace, table, targetRole, function types.String, functionArguments types.List) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, v := range []types.String{permission, resourceType, keyspace, table, targetRole, function} {
+		if v.IsUnknown() {
+			return diags
+		}
+	}
+	if functionArguments.IsUnknown() {
+		return diags
+	}
+
+	target, d := permissionResource(ctx, resourceType, keyspace, table, targetRole, function, functionArguments)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+	if err := target.Validate(); err != nil {
+		diags.AddAttributeError(
+			base.AtName("resource_type"),
+			"Invalid permission resource",
+			err.Error(),
+		)
+		return diags
+	}
+	if err := scylladb.ValidatePermission(permission.ValueString(), target.Type); err != nil {
+		diags.AddAttributeError(
+			base.AtName("permission"),
+			"Invalid permission",
+			err.Error(),
+		)
+	}
+	return diags
+}
+
+// grantID returns the ID of a grant.
+func grantID(p scylladb.Permission) string {
+	return p.Role + "|" + p.Permission + "|" + p.Resource.String()
+}
+
+// permissionLogFields returns the fields of a permission to log.
+func permissionLogFields(p scylladb.Permission) map[string]any {
+	return map[string]any{
+		"role":       p.Role,
+		"permission": p.Permission,
+		"resource":   p.Resource.String(),
+	}
+}
+
+// markdownList formats values as a comma separated list of code spans.
+func markdownList(values []string) string {
+	return "`" + strings.Join(values, "`, `") + "`"
+}
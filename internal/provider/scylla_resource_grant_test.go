@@ -0,0 +1,126 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/i1snow/terraform-provider-scylladb/internal/testutil"
+	"github.com/i1snow/terraform-provider-scylladb/scylladb"
+)
+
+func TestAccGrantResource(t *testing.T) {
+	devClusterHost := testutil.NewTestContainer(t)
+	providerConfig := fmt.Sprintf(providerConfigFmt, devClusterHost)
+	grantConfig := providerConfig + `
+resource "scylladb_role" "app" {
+  role = "app"
+}
+
+resource "scylladb_grant" "select_keyspace" {
+  role          = scylladb_role.app.role
+  permission    = "SELECT"
+  resource_type = "keyspace"
+  keyspace      = "app_ks"
+}
+
+resource "scylladb_grant" "modify_table" {
+  role          = scylladb_role.app.role
+  permission    = "MODIFY"
+  resource_type = "table"
+  keyspace      = "app_ks"
+  table         = "events"
+}
+
+resource "scylladb_grant" "describe_roles" {
+  role          = scylladb_role.app.role
+  permission    = "DESCRIBE"
+  resource_type = "all_roles"
+}
+
+resource "scylladb_grant" "execute_functions" {
+  role          = scylladb_role.app.role
+  permission    = "EXECUTE"
+  resource_type = "all_functions"
+  keyspace      = "app_ks"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Permissions that do not apply to the resource type fail at plan time
+			{
+				Config: providerConfig + `
+resource "scylladb_grant" "invalid" {
+  role          = "app"
+  permission    = "CREATE"
+  resource_type = "table"
+  keyspace      = "app_ks"
+  table         = "events"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid permission"),
+			},
+			{
+				Config: providerConfig + `
+resource "scylladb_grant" "invalid" {
+  role          = "app"
+  permission    = "SELECT"
+  resource_type = "table"
+  keyspace      = "app_ks"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("table is required"),
+			},
+			// Create and Read testing
+			{
+				PreConfig: func() {
+					cluster := testAccClient(t, devClusterHost)
+					for _, query := range []string{
+						`CREATE KEYSPACE app_ks WITH replication = {'class': 'NetworkTopologyStrategy', 'replication_factor': 1}`,
+						`CREATE TABLE app_ks.events (id int PRIMARY KEY)`,
+					} {
+						if err := cluster.Session.Query(query).Exec(); err != nil {
+							t.Fatalf("failed to prepare the keyspace: %s", err)
+						}
+					}
+				},
+				Config: grantConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("scylladb_grant.select_keyspace", "id", "app|SELECT|<keyspace app_ks>"),
+					resource.TestCheckResourceAttr("scylladb_grant.modify_table", "id", "app|MODIFY|<table app_ks.events>"),
+					resource.TestCheckResourceAttr("scylladb_grant.describe_roles", "id", "app|DESCRIBE|<all roles>"),
+					resource.TestCheckResourceAttr("scylladb_grant.execute_functions", "id", "app|EXECUTE|<all functions in app_ks>"),
+				),
+			},
+			// A permission revoked outside of Terraform is granted again
+			{
+				PreConfig: func() {
+					cluster := testAccClient(t, devClusterHost)
+					err := cluster.RevokePermission(scylladb.Permission{
+						Role:       "app",
+						Permission: "SELECT",
+						Resource:   scylladb.Resource{Type: scylladb.ResourceKeyspace, Keyspace: "app_ks"},
+					})
+					if err != nil {
+						t.Fatalf("failed to revoke the permission: %s", err)
+					}
+				},
+				Config:             grantConfig,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: grantConfig,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
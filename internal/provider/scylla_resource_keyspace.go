@@ -0,0 +1,761 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/i1snow/terraform-provider-scylladb/scylladb"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &keyspaceResource{}
+var _ resource.ResourceWithConfigure = &keyspaceResource{}
+var _ resource.ResourceWithValidateConfig = &keyspaceResource{}
+var _ resource.ResourceWithImportState = &keyspaceResource{}
+var _ resource.ResourceWithModifyPlan = &keyspaceResource{}
+
+func NewKeyspaceResource() resource.Resource {
+	return &keyspaceResource{}
+}
+
+// keyspaceResource defines the resource implementation.
+type keyspaceResource struct {
+	client *scylladb.Cluster
+}
+
+// keyspaceResourceModel maps the resource source schema data.
+type keyspaceResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+	ReplicationClass  types.String `tfsdk:"replication_class"`
+	ReplicationFactor types.Int64  `tfsdk:"replication_factor"`
+	DataCenters       types.Map    `tfsdk:"datacenters"`
+	DataCenterRacks   types.Map    `tfsdk:"datacenter_racks"`
+	DurableWrites     types.Bool   `tfsdk:"durable_writes"`
+	Tablets           types.Object `tfsdk:"tablets"`
+	// DeletionProtection and ForceDestroy only exist in Terraform and are
+	// read from the prior state.
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
+	ForceDestroy       types.Bool `tfsdk:"force_destroy"`
+}
+
+// keyspaceTabletsModel maps the tablets attribute.
+type keyspaceTabletsModel struct {
+	Enabled types.Bool  `tfsdk:"enabled"`
+	Initial types.Int64 `tfsdk:"initial"`
+}
+
+// keyspaceTabletsAttrTypes are the attribute types of the tablets attribute.
+var keyspaceTabletsAttrTypes = map[string]attr.Type{
+	"enabled": types.BoolType,
+	"initial": types.Int64Type,
+}
+
+// Metadata returns the resource type name.
+func (r *keyspaceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_keyspace"
+}
+
+// The resource uses the `Schema` method to define the supported configuration, plan, and state attribute names and types.
+func (r *keyspaceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	// Both the short and the fully qualified class names are accepted
+	replicationClasses := []string{}
+	for _, class := range scylladb.ReplicationClasses {
+		replicationClasses = append(replicationClasses, class, "org.apache.cassandra.locator."+class)
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a keyspace and its replication.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The name of the keyspace.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the keyspace. Changing it replaces the keyspace.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"replication_class": schema.StringAttribute{
+				Description: "The replication class, `NetworkTopologyStrategy` or `SimpleStrategy`. Fully qualified class names are accepted. Defaults to `NetworkTopologyStrategy`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(scylladb.NetworkTopologyStrategy),
+				Validators: []validator.String{
+					stringvalidator.OneOf(replicationClasses...),
+				},
+			},
+			"replication_factor": schema.Int64Attribute{
+				Description: "The replication factor of a `SimpleStrategy` keyspace.",
+				Optional:    true,
+			},
+			"datacenters": schema.MapAttribute{
+				Description: "The replication factor of each data center of a `NetworkTopologyStrategy` keyspace.",
+				Optional:    true,
+				ElementType: types.Int64Type,
+			},
+			"datacenter_racks": schema.MapAttribute{
+				Description: "The racks of each data center of a `NetworkTopologyStrategy` keyspace, which places one replica on each rack. " +
+					"A data center is either in `datacenters` or in `datacenter_racks`. The racks must exist in the cluster. Requires a ScyllaDB release that supports rack lists.",
+				Optional:    true,
+				ElementType: types.SetType{ElemType: types.StringType},
+			},
+			"durable_writes": schema.BoolAttribute{
+				Description: "Whether writes to the keyspace go through the commit log. Defaults to `true`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"tablets": schema.SingleNestedAttribute{
+				Description: "The tablets configuration of the keyspace. Defaults to the configuration of the cluster. " +
+					"Tablets cannot be turned on or off once the keyspace exists, so changing it replaces the keyspace.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"enabled": schema.BoolAttribute{
+						Description: "Whether the keyspace uses tablets rather than vnodes.",
+						Optional:    true,
+						Computed:    true,
+						PlanModifiers: []planmodifier.Bool{
+							boolplanmodifier.UseStateForUnknown(),
+							boolplanmodifier.RequiresReplace(),
+						},
+					},
+					"initial": schema.Int64Attribute{
+						Description: "The initial number of tablets of each table of the keyspace, which the cluster rounds up to a power of two. `0` lets the cluster decide, and is reported for keyspaces that do not use tablets.",
+						Optional:    true,
+						Computed:    true,
+						PlanModifiers: []planmodifier.Int64{
+							int64planmodifier.UseStateForUnknown(),
+							int64planmodifier.RequiresReplace(),
+						},
+						Validators: []validator.Int64{
+							int64validator.AtLeast(0),
+						},
+					},
+				},
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Description: "Refuse to plan the destruction or the replacement of the keyspace. " +
+					"To destroy the keyspace, apply this attribute set to `false` first. Defaults to `true`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"force_destroy": schema.BoolAttribute{
+				Description: "Drop the keyspace even when its tables hold data. " +
+					"Otherwise destroying a keyspace with a non-empty table fails. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+// Resources use the optional `Configure` method to fetch configured clients from the provider.
+func (r *keyspaceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*scylladb.Cluster)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *scylladb.Cluster, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// The provider uses the `ValidateConfig` method to check at plan time that
+// the replication attributes match the replication class.
+func (r *keyspaceResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config keyspaceResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !replicationKnown(config) {
+		return
+	}
+	// An unset class takes its default
+	if config.ReplicationClass.IsNull() {
+		config.ReplicationClass = types.StringValue(scylladb.NetworkTopologyStrategy)
+	}
+
+	replication, diags := keyspaceReplication(ctx, config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := replication.Validate(); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("replication_class"),
+			"Invalid replication",
+			err.Error(),
+		)
+	}
+}
+
+// The provider uses the `Create` method to create a new resource based on the schemadata.
+func (r *keyspaceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan keyspaceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keyspace, diags := planToKeyspace(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create the keyspace
+	tflog.Debug(ctx, "Creating keyspace", keyspaceLogFields(keyspace))
+	err := r.client.CreateKeyspace(keyspace)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create the keyspace",
+			err.Error(),
+		)
+		return
+	}
+
+	// Populate computed attribute values
+	plan.ID = types.StringValue(keyspace.Name)
+	created, err := r.client.GetKeyspace(keyspace.Name)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read the keyspace",
+			err.Error(),
+		)
+		return
+	}
+	plan.Tablets, diags = plannedTabletsValue(ctx, plan.Tablets, created.Tablets)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populate data
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// The provider uses the `Read` method to retrieve the resource's information and update the state
+// The provider invokes this function before every plan.
+func (r *keyspaceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state keyspaceResourceModel
+
+	// Read state.
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keyspace, err := r.client.GetKeyspace(state.ID.ValueString())
+	if errors.Is(err, scylladb.ErrKeyspaceNotFound) {
+		// The keyspace was dropped outside of Terraform, so let Terraform recreate it
+		tflog.Warn(ctx, "Keyspace not found, removing it from the state", map[string]any{"keyspace": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read the keyspace",
+			err.Error(),
+		)
+		return
+	}
+
+	// Keep the spelling of the class from the state when it designates the
+	// same class, so that short and long names do not cause a diff.
+	replicationClass := types.StringValue(keyspace.Replication.Class)
+	if scylladb.NormalizeReplicationClass(state.ReplicationClass.ValueString()) == keyspace.Replication.Class {
+		replicationClass = state.ReplicationClass
+	}
+
+	tablets, diags := readTabletsValue(ctx, state.Tablets, keyspace.Tablets)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Overwrite with refreshed state. The attributes that only exist in
+	// Terraform take their defaults when the keyspace is imported.
+	deletionProtection, forceDestroy := state.DeletionProtection, state.ForceDestroy
+	if deletionProtection.IsNull() {
+		deletionProtection = types.BoolValue(true)
+	}
+	if forceDestroy.IsNull() {
+		forceDestroy = types.BoolValue(false)
+	}
+	state = keyspaceResourceModel{
+		ID:                 types.StringValue(keyspace.Name),
+		Name:               types.StringValue(keyspace.Name),
+		ReplicationClass:   replicationClass,
+		ReplicationFactor:  types.Int64Null(),
+		DataCenters:        types.MapNull(types.Int64Type),
+		DataCenterRacks:    types.MapNull(types.SetType{ElemType: types.StringType}),
+		DurableWrites:      types.BoolValue(keyspace.DurableWrites),
+		Tablets:            tablets,
+		DeletionProtection: deletionProtection,
+		ForceDestroy:       forceDestroy,
+	}
+	if keyspace.Replication.ReplicationFactor > 0 {
+		state.ReplicationFactor = types.Int64Value(int64(keyspace.Replication.ReplicationFactor))
+	}
+	if len(keyspace.Replication.DataCenters) > 0 {
+		state.DataCenters = dataCentersValue(keyspace.Replication.DataCenters)
+	}
+	if len(keyspace.Replication.Racks) > 0 {
+		racks, diags := types.MapValueFrom(ctx, types.SetType{ElemType: types.StringType}, keyspace.Replication.Racks)
+		resp.Diagnostics.Append(diags...)
+		state.DataCenterRacks = racks
+	}
+
+	// Set state.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// The provider uses the `Update` method to update an existing resource based on the schema data.
+func (r *keyspaceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan keyspaceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keyspace, diags := planToKeyspace(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Alter the keyspace
+	tflog.Debug(ctx, "Altering keyspace", keyspaceLogFields(keyspace))
+	err := r.client.AlterKeyspace(ctx, keyspace)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to alter the keyspace",
+			err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// The provider uses the `Delete` method to attempt to retrieve the values from state and delete the resource.
+func (r *keyspaceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state keyspaceResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := state.Name.ValueString()
+	if !state.ForceDestroy.ValueBool() {
+		table, err := r.client.NonEmptyTable(name)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to check whether the keyspace is empty",
+				err.Error(),
+			)
+			return
+		}
+		if table != "" {
+			resp.Diagnostics.AddError(
+				"Keyspace is not empty",
+				fmt.Sprintf("The table %q of the keyspace %q holds data. Apply force_destroy = true on the keyspace to drop it anyway.", table, name),
+			)
+			return
+		}
+	}
+
+	// Drop the keyspace
+	tflog.Debug(ctx, "Dropping keyspace", map[string]any{"keyspace": name})
+	err := r.client.DropKeyspace(name)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to drop the keyspace",
+			err.Error(),
+		)
+		return
+	}
+}
+
+// The provider uses the `ModifyPlan` method to enforce the deletion
+// protection, to check the replication against the topology of the cluster
+// and to warn about the work a change of replication factor involves.
+func (r *keyspaceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var plan, state keyspaceResourceModel
+	if !req.Plan.Raw.IsNull() {
+		resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	}
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		replace, diags := planReplaces(ctx, req, resp, keyspaceReplaceAttributes)
+		resp.Diagnostics.Append(diags...)
+		resp.Diagnostics.Append(checkDeletionProtection("keyspace", state.Name.ValueString(), state.DeletionProtection, req.Plan.Raw.IsNull(), replace)...)
+	}
+	if req.Plan.Raw.IsNull() || !replicationKnown(plan) {
+		return
+	}
+	desired, diags := keyspaceReplication(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.checkTopology(desired)...)
+
+	// Only updates change the replication of existing data
+	if req.State.Raw.IsNull() {
+		return
+	}
+	current, diags := keyspaceReplication(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || current.Equal(desired) {
+		return
+	}
+
+	name := plan.Name.ValueString()
+	if current.Class != desired.Class {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("replication_class"),
+			"Replication class change",
+			fmt.Sprintf("Changing the replication class of %q moves replicas between nodes. Run a full repair of the keyspace once applied.", name),
+		)
+		return
+	}
+
+	changes := scylladb.DiffReplication(current, desired)
+	var increases, decreases []scylladb.ReplicationChange
+	for _, change := range changes {
+		if change.IsDecrease() {
+			decreases = append(decreases, change)
+		} else {
+			increases = append(increases, change)
+		}
+	}
+
+	var tablets keyspaceTabletsModel
+	if !state.Tablets.IsNull() {
+		resp.Diagnostics.Append(state.Tablets.As(ctx, &tablets, basetypes.ObjectAsOptions{})...)
+	}
+	if tablets.Enabled.ValueBool() {
+		steps := scylladb.ReplicationSteps(current, desired)
+		resp.Diagnostics.AddWarning(
+			"Stepwise replication factor change",
+			fmt.Sprintf("The replication of the tablets keyspace %q changes by one replica at a time, in %d steps (%s). "+
+				"Each step waits for the cluster to finish moving the tablets.", name, len(steps), scylladb.FormatReplicationChanges(changes)),
+		)
+		if len(decreases) > 0 {
+			resp.Diagnostics.AddWarning(
+				"Replication factor decrease",
+				fmt.Sprintf("Decreasing the replication factor of %q (%s) reduces the number of copies of the data and the consistency levels it can serve.",
+					name, scylladb.FormatReplicationChanges(decreases)),
+			)
+		}
+		return
+	}
+
+	if len(increases) > 0 {
+		resp.Diagnostics.AddWarning(
+			"Repair required after replication factor increase",
+			fmt.Sprintf("The new replicas of %q (%s) start empty. Run `nodetool repair -full %s` on every node of the affected data centers once applied.",
+				name, scylladb.FormatReplicationChanges(increases), name),
+		)
+	}
+	if len(decreases) > 0 {
+		resp.Diagnostics.AddWarning(
+			"Replication factor decrease",
+			fmt.Sprintf("Decreasing the replication factor of %q (%s) reduces the number of copies of the data and the consistency levels it can serve. "+
+				"Run `nodetool cleanup %s` on every node of the affected data centers once applied to reclaim the disk space.",
+				name, scylladb.FormatReplicationChanges(decreases), name),
+		)
+	}
+}
+
+// checkDeletionProtection fails the plan when it destroys or replaces a
+// keyspace or a table whose deletion protection is on in the prior state.
+func checkDeletionProtection(kind, name string, protected types.Bool, destroy, replace bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if !protected.ValueBool() {
+		return diags
+	}
+	action := ""
+	switch {
+	case destroy:
+		action = "destroy"
+	case replace:
+		action = "replace"
+	default:
+		return diags
+	}
+	diags.AddAttributeError(
+		path.Root("deletion_protection"),
+		fmt.Sprintf("The %s is protected against deletion", kind),
+		fmt.Sprintf("The plan would %s the %s %q, which drops its data. Apply deletion_protection = false on the %s first.", action, kind, name, kind),
+	)
+	return diags
+}
+
+// checkTopology checks that the data centers and racks of the replication
+// exist in the cluster.
+func (r *keyspaceResource) checkTopology(replication scylladb.Replication) diag.Diagnostics {
+	var diags diag.Diagnostics
+	// The provider is not configured yet when its configuration is unknown
+	if r.client == nil {
+		return diags
+	}
+	topology, err := r.client.GetTopology()
+	if err != nil {
+		diags.AddError(
+			"Unable to read the topology of the cluster",
+			err.Error(),
+		)
+		return diags
+	}
+	if err := topology.CheckReplication(replication); err != nil {
+		attribute := "datacenters"
+		if len(replication.Racks) > 0 {
+			attribute = "datacenter_racks"
+		}
+		diags.AddAttributeError(
+			path.Root(attribute),
+			"Invalid replication",
+			err.Error(),
+		)
+	}
+	return diags
+}
+
+// The provider users the `ImportState` method to import an existing keyspace by its name.
+func (r *keyspaceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Fail early with a clear message instead of importing an empty state
+	_, err := r.client.GetKeyspace(req.ID)
+	if errors.Is(err, scylladb.ErrKeyspaceNotFound) {
+		resp.Diagnostics.AddError(
+			"Keyspace not found",
+			fmt.Sprintf("Cannot import the keyspace %q because it does not exist in the cluster.", req.ID),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read the keyspace",
+			err.Error(),
+		)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// planToKeyspace converts the keyspace model into a scylladb.Keyspace.
+func planToKeyspace(ctx context.Context, plan keyspaceResourceModel) (scylladb.Keyspace, diag.Diagnostics) {
+	replication, diags := keyspaceReplication(ctx, plan)
+	keyspace := scylladb.Keyspace{
+		Name:          plan.Name.ValueString(),
+		Replication:   replication,
+		DurableWrites: plan.DurableWrites.ValueBool(),
+	}
+	tablets, d := modelTablets(ctx, plan.Tablets)
+	diags.Append(d...)
+	keyspace.Tablets = tablets
+	return keyspace, diags
+}
+
+// modelTablets converts the tablets attribute into a scylladb.Tablets, or
+// nil when it is not known.
+func modelTablets(ctx context.Context, value types.Object) (*scylladb.Tablets, diag.Diagnostics) {
+	if value.IsNull() || value.IsUnknown() {
+		return nil, nil
+	}
+	var tablets keyspaceTabletsModel
+	diags := value.As(ctx, &tablets, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true})
+	// Tablets are enabled when only the initial number is given
+	return &scylladb.Tablets{
+		Enabled: tablets.Enabled.IsNull() || tablets.Enabled.IsUnknown() || tablets.Enabled.ValueBool(),
+		Initial: int(tablets.Initial.ValueInt64()),
+	}, diags
+}
+
+// keyspaceReplication converts the replication attributes of the keyspace
+// model into a scylladb.Replication.
+func keyspaceReplication(ctx context.Context, model keyspaceResourceModel) (scylladb.Replication, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	replication := scylladb.Replication{
+		Class:             scylladb.NormalizeReplicationClass(model.ReplicationClass.ValueString()),
+		ReplicationFactor: int(model.ReplicationFactor.ValueInt64()),
+	}
+	if !model.DataCenters.IsNull() {
+		var dataCenters map[string]int64
+		diags.Append(model.DataCenters.ElementsAs(ctx, &dataCenters, false)...)
+		replication.DataCenters = make(map[string]int, len(dataCenters))
+		for dc, rf := range dataCenters {
+			replication.DataCenters[dc] = int(rf)
+		}
+	}
+	if !model.DataCenterRacks.IsNull() {
+		diags.Append(model.DataCenterRacks.ElementsAs(ctx, &replication.Racks, false)...)
+	}
+	return replication, diags
+}
+
+// replicationKnown reports whether every replication attribute of the
+// keyspace model is known.
+func replicationKnown(model keyspaceResourceModel) bool {
+	if model.ReplicationClass.IsUnknown() || model.ReplicationFactor.IsUnknown() ||
+		model.DataCenters.IsUnknown() || model.DataCenterRacks.IsUnknown() {
+		return false
+	}
+	for _, rf := range model.DataCenters.Elements() {
+		if rf.IsUnknown() {
+			return false
+		}
+	}
+	for _, racks := range model.DataCenterRacks.Elements() {
+		set, ok := racks.(types.Set)
+		if !ok || set.IsUnknown() {
+			return false
+		}
+		for _, rack := range set.Elements() {
+			if rack.IsUnknown() {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// dataCentersValue converts the replication factors of the data centers into
+// the datacenters map.
+func dataCentersValue(dataCenters map[string]int) types.Map {
+	elements := make(map[string]attr.Value, len(dataCenters))
+	for dc, rf := range dataCenters {
+		elements[dc] = types.Int64Value(int64(rf))
+	}
+	return types.MapValueMust(types.Int64Type, elements)
+}
+
+// plannedTabletsValue fills the unknown fields of the planned tablets
+// attribute from the tablets configuration read from the cluster. Configured
+// fields are kept as planned, even when the cluster normalizes them.
+func plannedTabletsValue(ctx context.Context, planned types.Object, tablets *scylladb.Tablets) (types.Object, diag.Diagnostics) {
+	if planned.IsNull() || planned.IsUnknown() {
+		return tabletsValue(tablets), nil
+	}
+	var model keyspaceTabletsModel
+	diags := planned.As(ctx, &model, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return planned, diags
+	}
+	if tablets == nil {
+		tablets = &scylladb.Tablets{}
+	}
+	if model.Enabled.IsUnknown() {
+		model.Enabled = types.BoolValue(tablets.Enabled)
+	}
+	if model.Initial.IsUnknown() {
+		model.Initial = types.Int64Value(int64(tablets.Initial))
+	}
+	value, d := types.ObjectValueFrom(ctx, keyspaceTabletsAttrTypes, model)
+	diags.Append(d...)
+	return value, diags
+}
+
+// readTabletsValue returns the tablets attribute to store after reading the
+// tablets configuration from the cluster. The prior value is kept when the
+// cluster only normalized it, see scylladb.NormalizeTablets, so that the
+// fields that replace the keyspace do not plan a replacement.
+func readTabletsValue(ctx context.Context, prior types.Object, tablets *scylladb.Tablets) (types.Object, diag.Diagnostics) {
+	priorTablets, diags := modelTablets(ctx, prior)
+	if diags.HasError() || priorTablets == nil || tablets == nil {
+		return tabletsValue(tablets), diags
+	}
+	if scylladb.NormalizeTablets(*priorTablets) == scylladb.NormalizeTablets(*tablets) {
+		return prior, diags
+	}
+	return tabletsValue(tablets), diags
+}
+
+// tabletsValue converts the tablets configuration read from the cluster into
+// the tablets attribute.
+func tabletsValue(tablets *scylladb.Tablets) types.Object {
+	if tablets == nil {
+		return types.ObjectNull(keyspaceTabletsAttrTypes)
+	}
+	return types.ObjectValueMust(keyspaceTabletsAttrTypes, map[string]attr.Value{
+		"enabled": types.BoolValue(tablets.Enabled),
+		"initial": types.Int64Value(int64(tablets.Initial)),
+	})
+}
+
+// keyspaceLogFields returns the fields of a keyspace to log.
+func keyspaceLogFields(keyspace scylladb.Keyspace) map[string]any {
+	return map[string]any{
+		"keyspace":           keyspace.Name,
+		"replication_class":  keyspace.Replication.Class,
+		"replication_factor": keyspace.Replication.ReplicationFactor,
+		"datacenters":        keyspace.Replication.DataCenters,
+		"datacenter_racks":   keyspace.Replication.Racks,
+		"durable_writes":     keyspace.DurableWrites,
+		"tablets":            keyspace.Tablets,
+	}
+}
@@ -0,0 +1,333 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/i1snow/terraform-provider-scylladb/internal/testutil"
+	"github.com/i1snow/terraform-provider-scylladb/scylladb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccKeyspaceResource(t *testing.T) {
+	devClusterHost := testutil.NewTestContainer(t)
+	providerConfig := fmt.Sprintf(providerConfigFmt, devClusterHost)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Replication attributes that do not match the class fail at plan time
+			{
+				Config: providerConfig + `
+resource "scylladb_keyspace" "invalid" {
+  name               = "app_ks"
+  replication_factor = 3
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid replication"),
+			},
+			// Racks are checked against the topology of the cluster
+			{
+				Config: providerConfig + `
+resource "scylladb_keyspace" "invalid" {
+  name = "app_ks"
+  datacenter_racks = {
+    datacenter1 = ["rack1", "rack9"]
+  }
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("unknown rack rack9 in data center datacenter1"),
+			},
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "scylladb_keyspace" "app" {
+  name                = "app_ks"
+  deletion_protection = false
+  datacenters = {
+    datacenter1 = 1
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("scylladb_keyspace.app", "id", "app_ks"),
+					resource.TestCheckResourceAttr("scylladb_keyspace.app", "replication_class", "NetworkTopologyStrategy"),
+					resource.TestCheckResourceAttr("scylladb_keyspace.app", "datacenters.datacenter1", "1"),
+					resource.TestCheckResourceAttr("scylladb_keyspace.app", "durable_writes", "true"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "scylladb_keyspace.app",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing. The fully qualified class name is kept as
+			// configured, so it does not cause a diff after apply.
+			{
+				Config: providerConfig + `
+resource "scylladb_keyspace" "app" {
+  name                = "app_ks"
+  replication_class   = "org.apache.cassandra.locator.NetworkTopologyStrategy"
+  deletion_protection = false
+  datacenters = {
+    datacenter1 = 1
+  }
+  durable_writes = false
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("scylladb_keyspace.app", "replication_class", "org.apache.cassandra.locator.NetworkTopologyStrategy"),
+					resource.TestCheckResourceAttr("scylladb_keyspace.app", "durable_writes", "false"),
+				),
+			},
+			// A keyspace dropped outside of Terraform is planned for creation
+			{
+				PreConfig: func() {
+					cluster := testAccClient(t, devClusterHost)
+					if err := cluster.DropKeyspace("app_ks"); err != nil {
+						t.Fatalf("failed to drop the keyspace: %s", err)
+					}
+				},
+				Config: providerConfig + `
+resource "scylladb_keyspace" "app" {
+  name                = "app_ks"
+  replication_class   = "org.apache.cassandra.locator.NetworkTopologyStrategy"
+  deletion_protection = false
+  datacenters = {
+    datacenter1 = 1
+  }
+  durable_writes = false
+}
+`,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Importing a missing keyspace fails with a clear error
+			{
+				ResourceName:  "scylladb_keyspace.app",
+				ImportState:   true,
+				ImportStateId: "missing",
+				ExpectError:   regexp.MustCompile("Keyspace not found"),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccKeyspaceResourceTablets(t *testing.T) {
+	devClusterHost := testutil.NewTestContainer(t)
+	providerConfig := fmt.Sprintf(providerConfigFmt, devClusterHost)
+	keyspaceConfig := providerConfig + `
+resource "scylladb_keyspace" "counters" {
+  name                = "counters_ks"
+  deletion_protection = false
+  datacenters = {
+    datacenter1 = 1
+  }
+  tablets = {
+    enabled = %t
+  }
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(keyspaceConfig, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("scylladb_keyspace.counters", "tablets.enabled", "false"),
+					resource.TestCheckResourceAttr("scylladb_keyspace.counters", "tablets.initial", "0"),
+				),
+			},
+			// Turning tablets on replaces the keyspace
+			{
+				Config: fmt.Sprintf(keyspaceConfig, true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("scylladb_keyspace.counters", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("scylladb_keyspace.counters", "tablets.enabled", "true"),
+				),
+			},
+			// The effective tablets are kept when the attribute is removed
+			{
+				Config: providerConfig + `
+resource "scylladb_keyspace" "counters" {
+  name                = "counters_ks"
+  deletion_protection = false
+  datacenters = {
+    datacenter1 = 1
+  }
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccKeyspaceResourceTabletsInitial(t *testing.T) {
+	devClusterHost := testutil.NewTestContainer(t)
+	providerConfig := fmt.Sprintf(providerConfigFmt, devClusterHost)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The configured initial number is kept and enabled is read back
+			{
+				Config: providerConfig + `
+resource "scylladb_keyspace" "events" {
+  name                = "events_ks"
+  deletion_protection = false
+  datacenters = {
+    datacenter1 = 1
+  }
+  tablets = {
+    initial = 8
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("scylladb_keyspace.events", "tablets.enabled", "true"),
+					resource.TestCheckResourceAttr("scylladb_keyspace.events", "tablets.initial", "8"),
+				),
+			},
+			// A number the cluster rounds up to a power of two does not
+			// replace the keyspace on every plan
+			{
+				Config: providerConfig + `
+resource "scylladb_keyspace" "events" {
+  name                = "events_ks"
+  deletion_protection = false
+  datacenters = {
+    datacenter1 = 1
+  }
+  tablets = {
+    initial = 6
+  }
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("scylladb_keyspace.events", plancheck.ResourceActionReplace),
+					},
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("scylladb_keyspace.events", "tablets.initial", "6"),
+				),
+			},
+		},
+	})
+}
+
+func TestReadTabletsValue(t *testing.T) {
+	ctx := context.Background()
+	configured := tabletsValue(&scylladb.Tablets{Enabled: true, Initial: 6})
+
+	value, diags := readTabletsValue(ctx, configured, &scylladb.Tablets{Enabled: true, Initial: 8})
+	assert.False(t, diags.HasError())
+	assert.Equal(t, configured, value)
+
+	value, diags = readTabletsValue(ctx, configured, &scylladb.Tablets{Enabled: true, Initial: 16})
+	assert.False(t, diags.HasError())
+	assert.Equal(t, tabletsValue(&scylladb.Tablets{Enabled: true, Initial: 16}), value)
+
+	value, diags = readTabletsValue(ctx, types.ObjectNull(keyspaceTabletsAttrTypes), &scylladb.Tablets{Enabled: true, Initial: 8})
+	assert.False(t, diags.HasError())
+	assert.Equal(t, tabletsValue(&scylladb.Tablets{Enabled: true, Initial: 8}), value)
+}
+
+func TestAccKeyspaceResourceDeletionProtection(t *testing.T) {
+	devClusterHost := testutil.NewTestContainer(t)
+	providerConfig := fmt.Sprintf(providerConfigFmt, devClusterHost)
+	keyspaceConfig := providerConfig + `
+resource "scylladb_keyspace" "orders" {
+  name                = "orders_ks"
+  deletion_protection = %t
+  force_destroy       = %t
+  datacenters = {
+    datacenter1 = 1
+  }
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "scylladb_keyspace" "orders" {
+  name = "orders_ks"
+  datacenters = {
+    datacenter1 = 1
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("scylladb_keyspace.orders", "deletion_protection", "true"),
+					resource.TestCheckResourceAttr("scylladb_keyspace.orders", "force_destroy", "false"),
+				),
+			},
+			// A protected keyspace cannot be destroyed or replaced
+			{
+				Config:      providerConfig,
+				ExpectError: regexp.MustCompile("The keyspace is protected against deletion"),
+			},
+			{
+				Config: providerConfig + `
+resource "scylladb_keyspace" "orders" {
+  name = "orders_v2_ks"
+  datacenters = {
+    datacenter1 = 1
+  }
+}
+`,
+				ExpectError: regexp.MustCompile("The keyspace is protected against deletion"),
+			},
+			// A keyspace with data is not dropped without force_destroy
+			{
+				PreConfig: func() {
+					cluster := testAccClient(t, devClusterHost)
+					for _, query := range []string{
+						`CREATE TABLE "orders_ks"."orders" (id int PRIMARY KEY)`,
+						`INSERT INTO "orders_ks"."orders" (id) VALUES (1)`,
+					} {
+						if err := cluster.Session.Query(query).Exec(); err != nil {
+							t.Fatalf("failed to write to the keyspace: %s", err)
+						}
+					}
+				},
+				Config: fmt.Sprintf(keyspaceConfig, false, false),
+			},
+			{
+				Config:      providerConfig,
+				ExpectError: regexp.MustCompile("Keyspace is not empty"),
+			},
+			{
+				Config: fmt.Sprintf(keyspaceConfig, false, true),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -5,15 +5,22 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/i1snow/terraform-provider-scylladb/scylladb"
 )
 
@@ -21,6 +28,7 @@ import (
 var _ resource.Resource = &roleResource{}
 var _ resource.ResourceWithConfigure = &roleResource{}
 var _ resource.ResourceWithImportState = &roleResource{}
+var _ resource.ResourceWithModifyPlan = &roleResource{}
 
 func NewRoleResource() resource.Resource {
 	return &roleResource{}
@@ -33,12 +41,21 @@ type roleResource struct {
 
 // roleResourceModel maps the resource source schema data.
 type roleResourceModel struct {
-	ID          types.String   `tfsdk:"id"`
-	LastUpdated types.String   `tfsdk:"last_updated"`
-	Role        types.String   `tfsdk:"role"`
-	CanLogin    types.Bool     `tfsdk:"can_login"`
-	IsSuperuser types.Bool     `tfsdk:"is_superuser"`
-	MemberOf    []types.String `tfsdk:"member_of"`
+	ID          types.String `tfsdk:"id"`
+	LastUpdated types.String `tfsdk:"last_updated"`
+	Role        types.String `tfsdk:"role"`
+	CanLogin    types.Bool   `tfsdk:"can_login"`
+	IsSuperuser types.Bool   `tfsdk:"is_superuser"`
+	MemberOf    types.Set    `tfsdk:"member_of"`
+	// PasswordWO and HashedPassword are write-only and therefore always null
+	// in the plan and state.
+	PasswordWO          types.String            `tfsdk:"password_wo"`
+	PasswordWOVersion   types.Int64             `tfsdk:"password_wo_version"`
+	HashedPassword      types.String            `tfsdk:"hashed_password"`
+	PasswordFingerprint types.String            `tfsdk:"password_fingerprint"`
+	Options             map[string]types.String `tfsdk:"options"`
+	// SkipLockoutProtection is only read from the prior state on destroy.
+	SkipLockoutProtection types.Bool `tfsdk:"skip_lockout_protection"`
 }
 
 // Metadata returns the resource type name.
@@ -75,10 +92,51 @@ func (r *roleResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Description: "whether the role is a superuser",
 				Optional:    true,
 			},
-			"member_of": schema.ListAttribute{
+			"member_of": schema.SetAttribute{
 				Optional:    true,
-				Description: "a list of members of the role",
+				Computed:    true,
+				Description: "a set of roles granted to the role. When set, any other membership of the role is revoked. Leave it unset to manage memberships with `scylladb_role_grant` instead.",
 				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"password_wo": schema.StringAttribute{
+				Description: "The password of the role. This value is write-only and is never stored in the state. Requires Terraform 1.11 or later.",
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("password_wo_version")),
+					stringvalidator.ConflictsWith(path.MatchRoot("hashed_password")),
+				},
+			},
+			"password_wo_version": schema.Int64Attribute{
+				Description: "The version of `password_wo`. Change this value to update the password of the role.",
+				Optional:    true,
+			},
+			"hashed_password": schema.StringAttribute{
+				Description: "A pre-computed password hash for the role, applied with `WITH HASHED PASSWORD`. This value is write-only and is never stored in the state. Requires Terraform 1.11 or later.",
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+			},
+			"options": schema.MapAttribute{
+				Description: "Custom options of the role, applied with `OPTIONS = {...}`. Only supported by custom role managers. Options the cluster does not report back are kept as configured.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"skip_lockout_protection": schema.BoolAttribute{
+				Description: "Allow dropping the role, or taking away its login or superuser status, even when it is the role the provider is authenticated as or the last superuser that can log in. " +
+					"To destroy such a role, apply this attribute set to `true` first.",
+				Optional: true,
+			},
+			"password_fingerprint": schema.StringAttribute{
+				Description: "A SHA-256 fingerprint of the password hash stored in the cluster, used to detect password changes made outside of Terraform.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 		},
 	}
@@ -113,10 +171,25 @@ func (r *roleResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	// Write-only attributes are only available in the configuration
+	var passwordWO, hashedPassword types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password_wo"), &passwordWO)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("hashed_password"), &hashedPassword)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Get role from plan
-	role := planToRole(plan)
+	role, diags := planToRole(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	role.Password = passwordWO.ValueString()
+	role.HashedPassword = hashedPassword.ValueString()
 
 	// Create a role
+	tflog.Debug(ctx, "Creating role", roleLogFields(role))
 	err := r.client.CreateRole(role)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -129,6 +202,13 @@ func (r *roleResource) Create(ctx context.Context, req resource.CreateRequest, r
 	// Populate computed attribute values
 	plan.ID = types.StringValue(role.Role)
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	if err := r.readComputed(&plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read the role",
+			err.Error(),
+		)
+		return
+	}
 
 	// Set state to fully populate data
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
@@ -149,6 +229,12 @@ func (r *roleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	curRole, err := r.client.GetRole(state.ID.ValueString())
+	if errors.Is(err, scylladb.ErrRoleNotFound) {
+		// The role was dropped outside of Terraform, so let Terraform recreate it
+		tflog.Warn(ctx, "Role not found, removing it from the state", map[string]any{"role": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to read the role",
@@ -157,16 +243,40 @@ func (r *roleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	// Overwrite with refreshed state.
+	// A fingerprint that no longer matches the cluster means the password
+	// was changed outside of Terraform.
+	fingerprint := passwordFingerprintValue(curRole.SaltedHash)
+	if !state.PasswordFingerprint.IsNull() && !state.PasswordFingerprint.Equal(fingerprint) {
+		resp.Diagnostics.AddWarning(
+			"Role password changed outside of Terraform",
+			fmt.Sprintf("The password of the role %q no longer matches the one applied by Terraform. "+
+				"A configured hashed_password will be re-applied on the next apply; "+
+				"when using password_wo, change password_wo_version to reset the password.", curRole.Role),
+		)
+	}
+
+	// Overwrite with refreshed state. The password version and the lockout
+	// override cannot be read back from the cluster, so they are carried over
+	// from the prior state.
 	state = roleResourceModel{
-		ID:          types.StringValue(curRole.Role),
-		Role:        types.StringValue(curRole.Role),
-		CanLogin:    types.BoolValue(curRole.CanLogin),
-		IsSuperuser: types.BoolValue(curRole.IsSuperuser),
-		LastUpdated: types.StringValue(time.Now().Format(time.RFC850)),
+		ID:                  types.StringValue(curRole.Role),
+		Role:                types.StringValue(curRole.Role),
+		CanLogin:            types.BoolValue(curRole.CanLogin),
+		IsSuperuser:         types.BoolValue(curRole.IsSuperuser),
+		LastUpdated:         types.StringValue(time.Now().Format(time.RFC850)),
+		MemberOf:            state.MemberOf,
+		PasswordWOVersion:   state.PasswordWOVersion,
+		PasswordFingerprint: fingerprint,
+		Options:             state.Options,
+
+		SkipLockoutProtection: state.SkipLockoutProtection,
+	}
+	if curRole.Options != nil {
+		state.Options = stringMapValue(curRole.Options)
 	}
-	for _, member := range curRole.MemberOf {
-		state.MemberOf = append(state.MemberOf, types.StringValue(member))
+	// Keep an explicitly empty set rather than flipping it to null
+	if len(curRole.MemberOf) > 0 || state.MemberOf.IsNull() || len(state.MemberOf.Elements()) > 0 {
+		state.MemberOf = memberOfValue(curRole.MemberOf)
 	}
 
 	// Set state.
@@ -185,10 +295,67 @@ func (r *roleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	var state roleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Get role from plan
-	role := planToRole(plan)
+	role, diags := planToRole(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Memberships are left alone unless they are configured
+	var configMemberOf types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("member_of"), &configMemberOf)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if configMemberOf.IsNull() {
+		role.MemberOf = nil
+	}
+
+	// Clear options that were removed from the configuration, but only when
+	// the cluster reports some: the built-in role manager rejects OPTIONS.
+	if role.Options == nil && state.Options != nil {
+		curRole, err := r.client.GetRole(role.Role)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to read the role",
+				err.Error(),
+			)
+			return
+		}
+		if curRole.Options != nil {
+			role.Options = map[string]string{}
+		}
+	}
+
+	// Only send the password when its version has changed
+	if !plan.PasswordWOVersion.Equal(state.PasswordWOVersion) {
+		var passwordWO types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password_wo"), &passwordWO)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		role.Password = passwordWO.ValueString()
+	}
+
+	// Only send the hashed password when it differs from the cluster
+	if !plan.PasswordFingerprint.Equal(state.PasswordFingerprint) {
+		var hashedPassword types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("hashed_password"), &hashedPassword)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		role.HashedPassword = hashedPassword.ValueString()
+	}
 
 	// Update the role
+	tflog.Debug(ctx, "Updating role", roleLogFields(role))
 	err := r.client.UpdateRole(role)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -200,6 +367,13 @@ func (r *roleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	// Populate Compuated attribute values
 	plan.ID = types.StringValue(role.Role)
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	if err := r.readComputed(&plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read the role",
+			err.Error(),
+		)
+		return
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
@@ -223,6 +397,7 @@ func (r *roleResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	}
 
 	// Delete the role
+	tflog.Debug(ctx, "Deleting role", roleLogFields(role))
 	err := r.client.DeleteRole(role)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -233,19 +408,216 @@ func (r *roleResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	}
 }
 
+// The provider uses the `ModifyPlan` method to plan the password fingerprint,
+// which Terraform cannot infer from the write-only password attributes, and
+// to refuse changes that would lock the administrators out of the cluster.
+func (r *roleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(r.checkLockout(ctx, req)...)
+
+	// Nothing to plan when the resource is destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan roleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	var hashedPassword types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("hashed_password"), &hashedPassword)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The cluster stores a hashed password as is, so its fingerprint is known
+	// in advance and any out-of-band change shows up as a diff.
+	if !hashedPassword.IsNull() {
+		fingerprint := types.StringUnknown()
+		if !hashedPassword.IsUnknown() {
+			fingerprint = passwordFingerprintValue(hashedPassword.ValueString())
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("password_fingerprint"), fingerprint)...)
+		return
+	}
+
+	// A new plaintext password gets a new salt, so the fingerprint is only
+	// known after apply.
+	if req.State.Raw.IsNull() {
+		return
+	}
+	var state roleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !plan.PasswordWOVersion.Equal(state.PasswordWOVersion) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("password_fingerprint"), types.StringUnknown())...)
+	}
+}
+
+// checkLockout refuses to drop the role, or to take away its login or
+// superuser status, when it is the role the provider is authenticated as or
+// the last superuser that can log in, unless skip_lockout_protection is set.
+func (r *roleResource) checkLockout(ctx context.Context, req resource.ModifyPlanRequest) diag.Diagnostics {
+	var diags diag.Diagnostics
+	// Nothing to check for new roles or before the provider is configured
+	if req.State.Raw.IsNull() || r.client == nil {
+		return diags
+	}
+
+	var state roleResourceModel
+	diags.Append(req.State.Get(ctx, &state)...)
+	if diags.HasError() {
+		return diags
+	}
+	current := scylladb.Role{
+		Role:        state.Role.ValueString(),
+		CanLogin:    state.CanLogin.ValueBool(),
+		IsSuperuser: state.IsSuperuser.ValueBool(),
+	}
+
+	var desired *scylladb.Role
+	skip := state.SkipLockoutProtection
+	if !req.Plan.Raw.IsNull() {
+		var plan roleResourceModel
+		diags.Append(req.Plan.Get(ctx, &plan)...)
+		if diags.HasError() {
+			return diags
+		}
+		skip = plan.SkipLockoutProtection
+		// Values only known at apply time are assumed unchanged
+		desired = &scylladb.Role{Role: current.Role, CanLogin: current.CanLogin, IsSuperuser: current.IsSuperuser}
+		if !plan.CanLogin.IsUnknown() {
+			desired.CanLogin = plan.CanLogin.ValueBool()
+		}
+		if !plan.IsSuperuser.IsUnknown() {
+			desired.IsSuperuser = plan.IsSuperuser.ValueBool()
+		}
+	}
+	if skip.ValueBool() {
+		return diags
+	}
+
+	err := r.client.CheckLockout(current, desired)
+	if errors.Is(err, scylladb.ErrLockout) {
+		diags.AddError(
+			"Role change would lock out the cluster",
+			err.Error()+". Set skip_lockout_protection = true on the role to apply the change anyway.",
+		)
+		return diags
+	}
+	if err != nil {
+		diags.AddError(
+			"Unable to check the role change",
+			err.Error(),
+		)
+	}
+	return diags
+}
+
 // The provider users the `ImportState` method to import an existing source.
 func (r *roleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Fail early with a clear message instead of importing an empty state
+	_, err := r.client.GetRole(req.ID)
+	if errors.Is(err, scylladb.ErrRoleNotFound) {
+		resp.Diagnostics.AddError(
+			"Role not found",
+			fmt.Sprintf("Cannot import the role %q because it does not exist in the cluster.", req.ID),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read the role",
+			err.Error(),
+		)
+		return
+	}
+
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
-func planToRole(plan roleResourceModel) scylladb.Role {
+func planToRole(ctx context.Context, plan roleResourceModel) (scylladb.Role, diag.Diagnostics) {
+	var diags diag.Diagnostics
 	role := scylladb.Role{
 		Role:        plan.Role.ValueString(),
 		CanLogin:    plan.CanLogin.ValueBool(),
 		IsSuperuser: plan.IsSuperuser.ValueBool(),
 	}
-	for _, member := range plan.MemberOf {
-		role.MemberOf = append(role.MemberOf, member.ValueString())
+	// An unknown member_of is not configured, so memberships are left alone
+	if !plan.MemberOf.IsNull() && !plan.MemberOf.IsUnknown() {
+		role.MemberOf = []string{}
+		diags.Append(plan.MemberOf.ElementsAs(ctx, &role.MemberOf, false)...)
+	}
+	if plan.Options != nil {
+		role.Options = make(map[string]string, len(plan.Options))
+		for k, v := range plan.Options {
+			role.Options[k] = v.ValueString()
+		}
+	}
+	return role, diags
+}
+
+// memberOfValue converts the memberships of a role into the member_of set,
+// which is null when the role has no memberships.
+func memberOfValue(memberOf []string) types.Set {
+	if len(memberOf) == 0 {
+		return types.SetNull(types.StringType)
+	}
+	elements := make([]attr.Value, 0, len(memberOf))
+	for _, member := range memberOf {
+		elements = append(elements, types.StringValue(member))
+	}
+	return types.SetValueMust(types.StringType, elements)
+}
+
+// stringMapValue converts a Go map into the map of a map attribute.
+func stringMapValue(m map[string]string) map[string]types.String {
+	if m == nil {
+		return nil
+	}
+	values := make(map[string]types.String, len(m))
+	for k, v := range m {
+		values[k] = types.StringValue(v)
+	}
+	return values
+}
+
+// readComputed fills the computed attributes that are still unknown after
+// an apply with the values stored in the cluster.
+func (r *roleResource) readComputed(plan *roleResourceModel) error {
+	if !plan.PasswordFingerprint.IsUnknown() && !plan.MemberOf.IsUnknown() {
+		return nil
+	}
+	curRole, err := r.client.GetRole(plan.Role.ValueString())
+	if err != nil {
+		return err
+	}
+	if plan.PasswordFingerprint.IsUnknown() {
+		plan.PasswordFingerprint = passwordFingerprintValue(curRole.SaltedHash)
+	}
+	if plan.MemberOf.IsUnknown() {
+		plan.MemberOf = memberOfValue(curRole.MemberOf)
+	}
+	return nil
+}
+
+// passwordFingerprintValue returns the fingerprint of a password hash, or
+// null when the role has no password.
+func passwordFingerprintValue(saltedHash string) types.String {
+	if saltedHash == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(scylladb.PasswordFingerprint(saltedHash))
+}
+
+// roleLogFields returns the fields of role that are safe to log. The password
+// is deliberately left out.
+func roleLogFields(role scylladb.Role) map[string]any {
+	return map[string]any{
+		"role":         role.Role,
+		"can_login":    role.CanLogin,
+		"is_superuser": role.IsSuperuser,
+		"member_of":    role.MemberOf,
+		"options":      role.Options,
+		"set_password": role.Password != "" || role.HashedPassword != "",
 	}
-	return role
 }
@@ -0,0 +1,280 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/i1snow/terraform-provider-scylladb/scylladb"
+)
+
+// roleGrantIDSeparator separates the role from the grantee in the ID of a
+// role grant. A separator or a backslash in a name is escaped with a
+// backslash.
+const roleGrantIDSeparator = "|"
+
+var roleGrantIDEscaper = strings.NewReplacer(`\`, `\\`, roleGrantIDSeparator, `\`+roleGrantIDSeparator)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &roleGrantResource{}
+var _ resource.ResourceWithConfigure = &roleGrantResource{}
+var _ resource.ResourceWithImportState = &roleGrantResource{}
+
+func NewRoleGrantResource() resource.Resource {
+	return &roleGrantResource{}
+}
+
+// roleGrantResource defines the resource implementation.
+type roleGrantResource struct {
+	client *scylladb.Cluster
+}
+
+// roleGrantResourceModel maps the resource source schema data.
+type roleGrantResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Role    types.String `tfsdk:"role"`
+	Grantee types.String `tfsdk:"grantee"`
+}
+
+// Metadata returns the resource type name.
+func (r *roleGrantResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_grant"
+}
+
+// The resource uses the `Schema` method to define the supported configuration, plan, and state attribute names and types.
+func (r *roleGrantResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Grants a single role to another role without taking ownership of the other memberships of the grantee. " +
+			"Do not combine it with `member_of` on the `scylladb_role` of the grantee.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The role and the grantee separated by `|`. A `|` or `\\` in a name is escaped with a `\\`.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"role": schema.StringAttribute{
+				Description: "The name of the role to grant",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"grantee": schema.StringAttribute{
+				Description: "The name of the role that receives the role",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+// Resources use the optional `Configure` method to fetch configured clients from the provider.
+func (r *roleGrantResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*scylladb.Cluster)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *scylladb.Cluster, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// The provider uses the `Create` method to create a new resource based on the schemadata.
+func (r *roleGrantResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan roleGrantResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Grant the role
+	role, grantee := plan.Role.ValueString(), plan.Grantee.ValueString()
+	tflog.Debug(ctx, "Granting role", map[string]any{"role": role, "grantee": grantee})
+	err := r.client.GrantRole(role, grantee)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to grant the role",
+			err.Error(),
+		)
+		return
+	}
+
+	// Populate computed attribute values
+	plan.ID = types.StringValue(roleGrantID(role, grantee))
+
+	// Set state to fully populate data
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// The provider uses the `Read` method to retrieve the resource's information and update the state
+// The provider invokes this function before every plan.
+func (r *roleGrantResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state roleGrantResourceModel
+
+	// Read state.
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	granted, err := r.client.HasRoleGrant(state.Role.ValueString(), state.Grantee.ValueString())
+	if err != nil && !errors.Is(err, scylladb.ErrRoleNotFound) {
+		resp.Diagnostics.AddError(
+			"Unable to read the role grant",
+			err.Error(),
+		)
+		return
+	}
+	if !granted {
+		// The membership was revoked outside of Terraform, so let Terraform grant it again
+		tflog.Warn(ctx, "Role grant not found, removing it from the state", map[string]any{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Set state.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// A role grant has no updatable attributes, every change replaces it.
+func (r *roleGrantResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan roleGrantResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// The provider uses the `Delete` method to attempt to retrieve the values from state and delete the resource.
+func (r *roleGrantResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state roleGrantResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Nothing to revoke when the membership or the grantee is already gone
+	role, grantee := state.Role.ValueString(), state.Grantee.ValueString()
+	granted, err := r.client.HasRoleGrant(role, grantee)
+	if errors.Is(err, scylladb.ErrRoleNotFound) || (err == nil && !granted) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read the role grant",
+			err.Error(),
+		)
+		return
+	}
+
+	// Revoke the role
+	tflog.Debug(ctx, "Revoking role", map[string]any{"role": role, "grantee": grantee})
+	err = r.client.RevokeRole(role, grantee)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to revoke the role",
+			err.Error(),
+		)
+		return
+	}
+}
+
+// The provider users the `ImportState` method to import an existing source.
+// The ID is the role and the grantee separated by `|`, see roleGrantID.
+func (r *roleGrantResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	role, grantee, err := parseRoleGrantID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid role grant ID",
+			err.Error(),
+		)
+		return
+	}
+
+	granted, err := r.client.HasRoleGrant(role, grantee)
+	if err != nil && !errors.Is(err, scylladb.ErrRoleNotFound) {
+		resp.Diagnostics.AddError(
+			"Unable to read the role grant",
+			err.Error(),
+		)
+		return
+	}
+	if !granted {
+		resp.Diagnostics.AddError(
+			"Role grant not found",
+			fmt.Sprintf("Cannot import the role grant %q because %q was not granted to %q.", req.ID, role, grantee),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("role"), role)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("grantee"), grantee)...)
+}
+
+// roleGrantID returns the ID of the grant of role to grantee.
+func roleGrantID(role, grantee string) string {
+	return roleGrantIDEscaper.Replace(role) + roleGrantIDSeparator + roleGrantIDEscaper.Replace(grantee)
+}
+
+// parseRoleGrantID splits a role grant ID into the role and the grantee,
+// unescaping them.
+func parseRoleGrantID(id string) (role, grantee string, err error) {
+	invalid := fmt.Errorf("expected an ID of the form <role>%s<grantee>, got %q", roleGrantIDSeparator, id)
+	var parts []string
+	var part strings.Builder
+	for i := 0; i < len(id); i++ {
+		switch id[i] {
+		case '\\':
+			i++
+			if i == len(id) {
+				return "", "", invalid
+			}
+			part.WriteByte(id[i])
+		case roleGrantIDSeparator[0]:
+			parts = append(parts, part.String())
+			part.Reset()
+		default:
+			part.WriteByte(id[i])
+		}
+	}
+	parts = append(parts, part.String())
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", invalid
+	}
+	return parts[0], parts[1], nil
+}
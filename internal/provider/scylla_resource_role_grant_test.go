@@ -0,0 +1,112 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/i1snow/terraform-provider-scylladb/internal/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccRoleGrantResource(t *testing.T) {
+	devClusterHost := testutil.NewTestContainer(t)
+	providerConfig := fmt.Sprintf(providerConfigFmt, devClusterHost)
+	grantConfig := providerConfig + `
+resource "scylladb_role" "reader" {
+  role = "reader"
+}
+
+resource "scylladb_role" "writer" {
+  role = "writer"
+}
+
+resource "scylladb_role" "app" {
+  role      = "app"
+  can_login = true
+}
+
+resource "scylladb_role_grant" "app_reader" {
+  role    = scylladb_role.reader.role
+  grantee = scylladb_role.app.role
+}
+
+resource "scylladb_role_grant" "app_writer" {
+  role    = scylladb_role.writer.role
+  grantee = scylladb_role.app.role
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: grantConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("scylladb_role_grant.app_reader", "id", "reader|app"),
+					resource.TestCheckResourceAttr("scylladb_role_grant.app_writer", "id", "writer|app"),
+				),
+			},
+			// Grants do not fight with a role that leaves member_of unset
+			{
+				Config:   grantConfig,
+				PlanOnly: true,
+			},
+			// ImportState testing
+			{
+				ResourceName:      "scylladb_role_grant.app_reader",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:  "scylladb_role_grant.app_reader",
+				ImportState:   true,
+				ImportStateId: "missing|app",
+				ExpectError:   regexp.MustCompile("Role grant not found"),
+			},
+			// A membership revoked outside of Terraform is granted again
+			{
+				PreConfig: func() {
+					cluster := testAccClient(t, devClusterHost)
+					if err := cluster.RevokeRole("writer", "app"); err != nil {
+						t.Fatalf("failed to revoke the role: %s", err)
+					}
+				},
+				Config:             grantConfig,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: grantConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("scylladb_role_grant.app_writer", "id", "writer|app"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestParseRoleGrantID(t *testing.T) {
+	role, grantee, err := parseRoleGrantID("reader|app-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "reader", role)
+	assert.Equal(t, "app-1", grantee)
+
+	for _, names := range [][2]string{{"reader", "app-1"}, {"a|b", "c"}, {"a", "b|c"}, {`a\|`, `\`}} {
+		role, grantee, err := parseRoleGrantID(roleGrantID(names[0], names[1]))
+		assert.NoError(t, err, names)
+		assert.Equal(t, names, [2]string{role, grantee})
+	}
+	assert.Equal(t, `a\|b|c`, roleGrantID("a|b", "c"))
+
+	for _, id := range []string{"reader", "reader|", "|app", "a|b|c", `reader|app\`} {
+		_, _, err := parseRoleGrantID(id)
+		assert.Error(t, err, id)
+	}
+}
@@ -0,0 +1,358 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/i1snow/terraform-provider-scylladb/scylladb"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &rolePermissionsResource{}
+var _ resource.ResourceWithConfigure = &rolePermissionsResource{}
+var _ resource.ResourceWithValidateConfig = &rolePermissionsResource{}
+var _ resource.ResourceWithImportState = &rolePermissionsResource{}
+
+func NewRolePermissionsResource() resource.Resource {
+	return &rolePermissionsResource{}
+}
+
+// rolePermissionsResource defines the resource implementation.
+type rolePermissionsResource struct {
+	client *scylladb.Cluster
+}
+
+// rolePermissionsResourceModel maps the resource source schema data.
+type rolePermissionsResourceModel struct {
+	ID          types.String          `tfsdk:"id"`
+	Role        types.String          `tfsdk:"role"`
+	Permissions []rolePermissionModel `tfsdk:"permissions"`
+}
+
+// rolePermissionModel maps a single permission of the permissions set.
+type rolePermissionModel struct {
+	Permission        types.String `tfsdk:"permission"`
+	ResourceType      types.String `tfsdk:"resource_type"`
+	Keyspace          types.String `tfsdk:"keyspace"`
+	Table             types.String `tfsdk:"table"`
+	TargetRole        types.String `tfsdk:"target_role"`
+	Function          types.String `tfsdk:"function"`
+	FunctionArguments types.List   `tfsdk:"function_arguments"`
+}
+
+// Metadata returns the resource type name.
+func (r *rolePermissionsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_permissions"
+}
+
+// The resource uses the `Schema` method to define the supported configuration, plan, and state attribute names and types.
+func (r *rolePermissionsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	permissionAttributes := permissionResourceAttributes(false)
+	permissionAttributes["permission"] = schema.StringAttribute{
+		Description: "The permission to grant, one of " + markdownList(scylladb.Permissions) + ".",
+		Required:    true,
+		Validators: []validator.String{
+			stringvalidator.OneOf(scylladb.Permissions...),
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Owns the entire set of permissions directly granted to a role. " +
+			"Any permission of the role that is not declared is revoked. " +
+			"Do not combine it with `scylladb_grant` on the same role.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The name of the role.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"role": schema.StringAttribute{
+				Description: "The name of the role that receives the permissions",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"permissions": schema.SetNestedAttribute{
+				Description: "The permissions of the role. An empty set revokes every permission of the role.",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: permissionAttributes,
+				},
+			},
+		},
+	}
+}
+
+// Resources use the optional `Configure` method to fetch configured clients from the provider.
+func (r *rolePermissionsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*scylladb.Cluster)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *scylladb.Cluster, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// The provider uses the `ValidateConfig` method to check at plan time that
+// every permission applies to its resource type.
+func (r *rolePermissionsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var permissions types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("permissions"), &permissions)...)
+	if resp.Diagnostics.HasError() || permissions.IsNull() || permissions.IsUnknown() {
+		return
+	}
+
+	for _, element := range permissions.Elements() {
+		object, ok := element.(types.Object)
+		if !ok || object.IsUnknown() {
+			continue
+		}
+		var p rolePermissionModel
+		resp.Diagnostics.Append(object.As(ctx, &p, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(validatePermissionConfig(ctx, path.Root("permissions").AtSetValue(element), p.Permission,
+			p.ResourceType, p.Keyspace, p.Table, p.TargetRole, p.Function, p.FunctionArguments)...)
+	}
+}
+
+// The provider uses the `Create` method to create a new resource based on the schemadata.
+func (r *rolePermissionsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan rolePermissionsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.sync(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Populate computed attribute values
+	plan.ID = plan.Role
+
+	// Set state to fully populate data
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// The provider uses the `Read` method to retrieve the resource's information and update the state
+// The provider invokes this function before every plan.
+func (r *rolePermissionsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state rolePermissionsResourceModel
+
+	// Read state.
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	role := state.ID.ValueString()
+	current, err := r.client.ListPermissions(role, false)
+	if errors.Is(err, scylladb.ErrRoleNotFound) {
+		// The role was dropped outside of Terraform, so let Terraform create it again
+		tflog.Warn(ctx, "Role not found, removing its permissions from the state", map[string]any{"role": role})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read the permissions of the role",
+			err.Error(),
+		)
+		return
+	}
+
+	known, diags := rolePermissionsToPermissions(ctx, role, state.Permissions)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep the permissions from the state that are still granted, so their
+	// formatting is preserved, and add the ones granted outside of Terraform.
+	missing, extra := scylladb.DiffPermissions(current, known)
+	permissions := []rolePermissionModel{}
+	for i, p := range known {
+		if !slices.ContainsFunc(missing, p.Equal) {
+			permissions = append(permissions, state.Permissions[i])
+		}
+	}
+	for _, p := range extra {
+		permissions = append(permissions, rolePermissionValue(p))
+	}
+
+	state.ID = types.StringValue(role)
+	state.Role = types.StringValue(role)
+	state.Permissions = permissions
+
+	// Set state.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// The provider uses the `Update` method to update the permissions of the role.
+func (r *rolePermissionsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan rolePermissionsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.sync(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// The provider uses the `Delete` method to revoke every permission of the role.
+func (r *rolePermissionsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state rolePermissionsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	role := state.Role.ValueString()
+	tflog.Debug(ctx, "Revoking every permission of the role", map[string]any{"role": role})
+	err := r.client.SyncRolePermissions(role, nil)
+	// Nothing to revoke when the role is already gone
+	if err != nil && !errors.Is(err, scylladb.ErrRoleNotFound) {
+		resp.Diagnostics.AddError(
+			"Unable to revoke the permissions of the role",
+			err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports the permissions of a role by its name.
+func (r *rolePermissionsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if _, err := r.client.GetRole(req.ID); err != nil {
+		if errors.Is(err, scylladb.ErrRoleNotFound) {
+			resp.Diagnostics.AddError(
+				"Role not found",
+				fmt.Sprintf("The role %q does not exist in the cluster.", req.ID),
+			)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unable to read the role",
+			err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("role"), req.ID)...)
+}
+
+// sync makes the permissions of the plan the exact permissions of the role.
+func (r *rolePermissionsResource) sync(ctx context.Context, plan rolePermissionsResourceModel) diag.Diagnostics {
+	role := plan.Role.ValueString()
+	permissions, diags := rolePermissionsToPermissions(ctx, role, plan.Permissions)
+	if diags.HasError() {
+		return diags
+	}
+
+	tflog.Debug(ctx, "Syncing the permissions of the role", map[string]any{"role": role, "permissions": len(permissions)})
+	if err := r.client.SyncRolePermissions(role, permissions); err != nil {
+		diags.AddError(
+			"Unable to update the permissions of the role",
+			err.Error(),
+		)
+	}
+	return diags
+}
+
+// rolePermissionsToPermissions converts the permissions set of role into
+// scylladb.Permission values, in the same order.
+func rolePermissionsToPermissions(ctx context.Context, role string, models []rolePermissionModel) ([]scylladb.Permission, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	permissions := make([]scylladb.Permission, 0, len(models))
+	for _, m := range models {
+		target, d := permissionResource(ctx, m.ResourceType, m.Keyspace, m.Table, m.TargetRole, m.Function, m.FunctionArguments)
+		diags.Append(d...)
+		permissions = append(permissions, scylladb.Permission{
+			Role:       role,
+			Permission: m.Permission.ValueString(),
+			Resource:   target,
+		})
+	}
+	return permissions, diags
+}
+
+// rolePermissionValue converts a permission read from the cluster into an
+// element of the permissions set. Unused resource attributes are null.
+func rolePermissionValue(p scylladb.Permission) rolePermissionModel {
+	m := rolePermissionModel{
+		Permission:        types.StringValue(p.Permission),
+		ResourceType:      types.StringValue(string(p.Resource.Type)),
+		Keyspace:          optionalStringValue(p.Resource.Keyspace),
+		Table:             optionalStringValue(p.Resource.Table),
+		TargetRole:        optionalStringValue(p.Resource.Role),
+		Function:          optionalStringValue(p.Resource.Function),
+		FunctionArguments: types.ListNull(types.StringType),
+	}
+	if len(p.Resource.FunctionArguments) > 0 {
+		elements := make([]attr.Value, 0, len(p.Resource.FunctionArguments))
+		for _, argument := range p.Resource.FunctionArguments {
+			elements = append(elements, types.StringValue(argument))
+		}
+		m.FunctionArguments = types.ListValueMust(types.StringType, elements)
+	}
+	return m
+}
+
+// optionalStringValue converts an optional string into a string attribute,
+// which is null when the string is empty.
+func optionalStringValue(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/i1snow/terraform-provider-scylladb/internal/testutil"
+	"github.com/i1snow/terraform-provider-scylladb/scylladb"
+)
+
+func TestAccRolePermissionsResource(t *testing.T) {
+	devClusterHost := testutil.NewTestContainer(t)
+	providerConfig := fmt.Sprintf(providerConfigFmt, devClusterHost)
+	permissionsConfig := providerConfig + `
+resource "scylladb_role" "service" {
+  role = "service"
+}
+
+resource "scylladb_role_permissions" "service" {
+  role = scylladb_role.service.role
+  permissions = [
+    {
+      permission    = "SELECT"
+      resource_type = "keyspace"
+      keyspace      = "app_ks"
+    },
+    {
+      permission    = "MODIFY"
+      resource_type = "table"
+      keyspace      = "app_ks"
+      table         = "events"
+    },
+  ]
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Permissions that do not apply to the resource type fail at plan time
+			{
+				Config: providerConfig + `
+resource "scylladb_role_permissions" "invalid" {
+  role = "service"
+  permissions = [
+    {
+      permission    = "EXECUTE"
+      resource_type = "keyspace"
+      keyspace      = "app_ks"
+    },
+  ]
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid permission"),
+			},
+			// Create and Read testing
+			{
+				PreConfig: func() {
+					cluster := testAccClient(t, devClusterHost)
+					for _, query := range []string{
+						`CREATE KEYSPACE app_ks WITH replication = {'class': 'NetworkTopologyStrategy', 'replication_factor': 1}`,
+						`CREATE TABLE app_ks.events (id int PRIMARY KEY)`,
+					} {
+						if err := cluster.Session.Query(query).Exec(); err != nil {
+							t.Fatalf("failed to prepare the keyspace: %s", err)
+						}
+					}
+				},
+				Config: permissionsConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("scylladb_role_permissions.service", "id", "service"),
+					resource.TestCheckResourceAttr("scylladb_role_permissions.service", "permissions.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("scylladb_role_permissions.service", "permissions.*", map[string]string{
+						"permission":    "MODIFY",
+						"resource_type": "table",
+						"keyspace":      "app_ks",
+						"table":         "events",
+					}),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "scylladb_role_permissions.service",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// A permission granted outside of Terraform shows up as drift
+			{
+				PreConfig: func() {
+					cluster := testAccClient(t, devClusterHost)
+					err := cluster.GrantPermission(scylladb.Permission{
+						Role:       "service",
+						Permission: "DROP",
+						Resource:   scylladb.Resource{Type: scylladb.ResourceAllKeyspaces},
+					})
+					if err != nil {
+						t.Fatalf("failed to grant the permission: %s", err)
+					}
+				},
+				Config:             permissionsConfig,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Applying revokes it
+			{
+				Config: permissionsConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("scylladb_role_permissions.service", "permissions.#", "2"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -5,10 +5,12 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/i1snow/terraform-provider-scylladb/internal/testutil"
+	"github.com/i1snow/terraform-provider-scylladb/scylladb"
 )
 
 func TestAccRoleResource(t *testing.T) {
@@ -58,7 +60,235 @@ resource "scylladb_role" "admin" {
 					resource.TestCheckResourceAttr("scylladb_role.admin", "is_superuser", "false"),
 				),
 			},
+			// Membership testing
+			{
+				Config: providerConfig + `
+resource "scylladb_role" "reader" {
+    role = "reader"
+}
+
+resource "scylladb_role" "writer" {
+    role = "writer"
+}
+
+resource "scylladb_role" "admin" {
+    role = "admin"
+    can_login = true
+    is_superuser = false
+    member_of = [scylladb_role.writer.role, scylladb_role.reader.role]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("scylladb_role.admin", "member_of.#", "2"),
+					resource.TestCheckTypeSetElemAttr("scylladb_role.admin", "member_of.*", "reader"),
+					resource.TestCheckTypeSetElemAttr("scylladb_role.admin", "member_of.*", "writer"),
+				),
+			},
+			{
+				Config: providerConfig + `
+resource "scylladb_role" "reader" {
+    role = "reader"
+}
+
+resource "scylladb_role" "writer" {
+    role = "writer"
+}
+
+resource "scylladb_role" "admin" {
+    role = "admin"
+    can_login = true
+    is_superuser = false
+    member_of = [scylladb_role.reader.role]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("scylladb_role.admin", "member_of.#", "1"),
+					resource.TestCheckTypeSetElemAttr("scylladb_role.admin", "member_of.*", "reader"),
+				),
+			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
 }
+
+func TestAccRoleResourcePassword(t *testing.T) {
+	devClusterHost := testutil.NewTestContainer(t)
+	providerConfig := fmt.Sprintf(providerConfigFmt, devClusterHost)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create with a write-only password
+			{
+				Config: providerConfig + `
+resource "scylladb_role" "app" {
+    role = "app"
+    can_login = true
+    password_wo = "first-secret"
+    password_wo_version = 1
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("scylladb_role.app", "can_login", "true"),
+					resource.TestCheckResourceAttr("scylladb_role.app", "password_wo_version", "1"),
+					resource.TestCheckNoResourceAttr("scylladb_role.app", "password_wo"),
+				),
+			},
+			// Rotate the password
+			{
+				Config: providerConfig + `
+resource "scylladb_role" "app" {
+    role = "app"
+    can_login = true
+    password_wo = "second-secret"
+    password_wo_version = 2
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("scylladb_role.app", "password_wo_version", "2"),
+					resource.TestCheckNoResourceAttr("scylladb_role.app", "password_wo"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRoleResourceHashedPassword(t *testing.T) {
+	devClusterHost := testutil.NewTestContainer(t)
+	providerConfig := fmt.Sprintf(providerConfigFmt, devClusterHost)
+	hashedPassword := "$6$tfacctestsalt$BniOjTkRcVk8K8gxB7iAOgQPlNlKyaeqTqaZwvx.w9EHIwRQyxbhgvq7ah23k7WZiYrIAb.v.T6R3zPwdzeae1"
+	roleConfig := providerConfig + fmt.Sprintf(`
+resource "scylladb_role" "app" {
+    role = "app"
+    can_login = true
+    hashed_password = %q
+}
+`, hashedPassword)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create with a pre-computed hash
+			{
+				Config: roleConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("scylladb_role.app", "hashed_password"),
+					resource.TestCheckResourceAttr("scylladb_role.app", "password_fingerprint", scylladb.PasswordFingerprint(hashedPassword)),
+				),
+			},
+			// Changing the password out of band shows up as a diff
+			{
+				PreConfig: func() {
+					cluster := testAccClient(t, devClusterHost)
+					if err := cluster.UpdateRole(scylladb.Role{Role: "app", CanLogin: true, Password: "changed"}); err != nil {
+						t.Fatalf("failed to update the role: %s", err)
+					}
+				},
+				Config:             roleConfig,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Re-apply the hashed password
+			{
+				Config: roleConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("scylladb_role.app", "password_fingerprint", scylladb.PasswordFingerprint(hashedPassword)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRoleResourceDroppedOutOfBand(t *testing.T) {
+	devClusterHost := testutil.NewTestContainer(t)
+	providerConfig := fmt.Sprintf(providerConfigFmt, devClusterHost)
+	roleConfig := providerConfig + `
+resource "scylladb_role" "admin" {
+    role = "admin"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: roleConfig,
+			},
+			// A role dropped outside of Terraform is planned for creation
+			{
+				PreConfig: func() {
+					cluster := testAccClient(t, devClusterHost)
+					if err := cluster.DeleteRole(scylladb.Role{Role: "admin"}); err != nil {
+						t.Fatalf("failed to delete the role: %s", err)
+					}
+				},
+				Config:             roleConfig,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: roleConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("scylladb_role.admin", "role", "admin"),
+				),
+			},
+			// Importing a missing role fails with a clear error
+			{
+				ResourceName:  "scylladb_role.admin",
+				ImportState:   true,
+				ImportStateId: "missing",
+				ExpectError:   regexp.MustCompile("Role not found"),
+			},
+		},
+	})
+}
+
+func TestAccRoleResourceLockoutProtection(t *testing.T) {
+	devClusterHost := testutil.NewTestContainer(t)
+	providerConfig := fmt.Sprintf(providerConfigFmt, devClusterHost)
+	cassandraConfig := providerConfig + `
+import {
+  to = scylladb_role.cassandra
+  id = "cassandra"
+}
+
+resource "scylladb_role" "cassandra" {
+  role         = "cassandra"
+  can_login    = %t
+  is_superuser = true
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(cassandraConfig, true),
+			},
+			// The provider cannot disable the login of the role it is authenticated as
+			{
+				Config:      fmt.Sprintf(cassandraConfig, false),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Role change would lock out the cluster"),
+			},
+			// Nor drop it
+			{
+				Config:      providerConfig,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("authenticated as and cannot be dropped"),
+			},
+			// Keep the role when the test ends
+			{
+				Config: providerConfig + `
+removed {
+  from = scylladb_role.cassandra
+
+  lifecycle {
+    destroy = false
+  }
+}
+`,
+			},
+		},
+	})
+}
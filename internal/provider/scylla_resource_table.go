@@ -0,0 +1,649 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/i1snow/terraform-provider-scylladb/scylladb"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &tableResource{}
+var _ resource.ResourceWithConfigure = &tableResource{}
+var _ resource.ResourceWithValidateConfig = &tableResource{}
+var _ resource.ResourceWithImportState = &tableResource{}
+var _ resource.ResourceWithModifyPlan = &tableResource{}
+
+// tableIDSeparator separates the keyspace from the table in the ID of a
+// table. Keyspace names cannot contain it.
+const tableIDSeparator = "."
+
+func NewTableResource() resource.Resource {
+	return &tableResource{}
+}
+
+// tableResource defines the resource implementation.
+type tableResource struct {
+	client *scylladb.Cluster
+}
+
+// tableResourceModel maps the resource source schema data.
+type tableResourceModel struct {
+	ID            types.String                 `tfsdk:"id"`
+	Keyspace      types.String                 `tfsdk:"keyspace"`
+	Name          types.String                 `tfsdk:"name"`
+	Columns       []tableColumnModel           `tfsdk:"columns"`
+	PartitionKey  []types.String               `tfsdk:"partition_key"`
+	ClusteringKey []tableClusteringColumnModel `tfsdk:"clustering_key"`
+	// The options of the table, see scylla_resource_table_options.go
+	Comment                 types.String  `tfsdk:"comment"`
+	DefaultTimeToLive       types.Int64   `tfsdk:"default_time_to_live"`
+	GCGraceSeconds          types.Int64   `tfsdk:"gc_grace_seconds"`
+	BloomFilterFPChance     types.Float64 `tfsdk:"bloom_filter_fp_chance"`
+	SpeculativeRetry        types.String  `tfsdk:"speculative_retry"`
+	PaxosGraceSeconds       types.Int64   `tfsdk:"paxos_grace_seconds"`
+	MemtableFlushPeriodInMs types.Int64   `tfsdk:"memtable_flush_period_in_ms"`
+	Compaction              types.Object  `tfsdk:"compaction"`
+	Compression             types.Object  `tfsdk:"compression"`
+	Caching                 types.Object  `tfsdk:"caching"`
+	CDC                     types.Object  `tfsdk:"cdc"`
+	TombstoneGC             types.Object  `tfsdk:"tombstone_gc"`
+	PerPartitionRateLimit   types.Object  `tfsdk:"per_partition_rate_limit"`
+	// DeletionProtection and ForceDestroy only exist in Terraform and are
+	// read from the prior state.
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
+	ForceDestroy       types.Bool `tfsdk:"force_destroy"`
+}
+
+// tableColumnModel maps a single column of the columns list.
+type tableColumnModel struct {
+	Name   types.String `tfsdk:"name"`
+	Type   types.String `tfsdk:"type"`
+	Static types.Bool   `tfsdk:"static"`
+}
+
+// tableClusteringColumnModel maps a single column of the clustering key.
+type tableClusteringColumnModel struct {
+	Name  types.String `tfsdk:"name"`
+	Order types.String `tfsdk:"order"`
+}
+
+// Metadata returns the resource type name.
+func (r *tableResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_table"
+}
+
+// The resource uses the `Schema` method to define the supported configuration, plan, and state attribute names and types.
+func (r *tableResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Description: "The keyspace and the name of the table separated by a dot.",
+			Computed:    true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"keyspace": schema.StringAttribute{
+			Description: "The keyspace of the table. Changing it replaces the table.",
+			Required:    true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"name": schema.StringAttribute{
+			Description: "The name of the table. Changing it replaces the table.",
+			Required:    true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"columns": schema.ListNestedAttribute{
+			Description: "The columns of the table, key columns included.",
+			Required:    true,
+			Validators: []validator.List{
+				listvalidator.SizeAtLeast(1),
+			},
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Description: "The name of the column.",
+						Required:    true,
+					},
+					"type": schema.StringAttribute{
+						Description: "The CQL type of the column, such as `text`, `frozen<map<text, list<int>>>` or `vector<float, 768>`. " +
+							"Types are compared once normalized, so `MAP<Text, INT>` is the same as `map<text, int>`. Changing it replaces the table.",
+						Required: true,
+					},
+					"static": schema.BoolAttribute{
+						Description: "Whether the column is shared by all the rows of a partition. Requires a clustering key. Changing it replaces the table. Defaults to `false`.",
+						Optional:    true,
+						Computed:    true,
+						Default:     booldefault.StaticBool(false),
+					},
+				},
+			},
+		},
+		"partition_key": schema.ListAttribute{
+			Description: "The columns of the partition key, in order. Changing it replaces the table.",
+			Required:    true,
+			ElementType: types.StringType,
+			Validators: []validator.List{
+				listvalidator.SizeAtLeast(1),
+			},
+			PlanModifiers: []planmodifier.List{
+				listplanmodifier.RequiresReplace(),
+			},
+		},
+		"clustering_key": schema.ListNestedAttribute{
+			Description: "The columns of the clustering key, in order. Changing it replaces the table.",
+			Optional:    true,
+			PlanModifiers: []planmodifier.List{
+				listplanmodifier.RequiresReplace(),
+			},
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Description: "The name of the column.",
+						Required:    true,
+					},
+					"order": schema.StringAttribute{
+						Description: "The clustering order of the column, `ASC` or `DESC`. Defaults to `ASC`.",
+						Optional:    true,
+						Computed:    true,
+						Default:     stringdefault.StaticString(scylladb.ClusteringOrderAsc),
+						Validators: []validator.String{
+							stringvalidator.OneOf(scylladb.ClusteringOrderAsc, scylladb.ClusteringOrderDesc),
+						},
+					},
+				},
+			},
+		},
+		"deletion_protection": schema.BoolAttribute{
+			Description: "Refuse to plan the destruction or the replacement of the table. " +
+				"To destroy the table, apply this attribute set to `false` first. Defaults to `true`.",
+			Optional: true,
+			Computed: true,
+			Default:  booldefault.StaticBool(true),
+		},
+		"force_destroy": schema.BoolAttribute{
+			Description: "Drop the table even when it holds data. Otherwise destroying a non-empty table fails. Defaults to `false`.",
+			Optional:    true,
+			Computed:    true,
+			Default:     booldefault.StaticBool(false),
+		},
+	}
+	maps.Copy(attributes, tableOptionsAttributes())
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a table, its columns, its primary key and its options. " +
+			"Columns that are not part of the primary key are added and dropped in place and options are altered in place, " +
+			"while changing the primary key, the type of a column or whether it is static replaces the table.",
+		Attributes: attributes,
+	}
+}
+
+// Resources use the optional `Configure` method to fetch configured clients from the provider.
+func (r *tableResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*scylladb.Cluster)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *scylladb.Cluster, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// The provider uses the `ValidateConfig` method to check at plan time that
+// the types of the columns parse, that the primary key refers to the columns
+// of the table and that the options are valid.
+func (r *tableResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config tableResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	options, diags := tableOptions(ctx, config)
+	resp.Diagnostics.Append(diags...)
+	if err := options.Validate(); err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid table options",
+			err.Error(),
+		)
+	}
+	// Point at the column with an invalid type
+	for i, column := range config.Columns {
+		if !known(column.Type) {
+			continue
+		}
+		if _, err := scylladb.ParseType(column.Type.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("columns").AtListIndex(i).AtName("type"),
+				"Invalid column type",
+				fmt.Sprintf("Column %s: %s", column.Name.ValueString(), err),
+			)
+		}
+	}
+	if resp.Diagnostics.HasError() || !tableKnown(config) {
+		return
+	}
+
+	if err := planToTable(config).Validate(); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("columns"),
+			"Invalid table",
+			err.Error(),
+		)
+	}
+}
+
+// The provider uses the `Create` method to create a new resource based on the schemadata.
+func (r *tableResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan tableResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create the table
+	table := planToTable(plan)
+	options, diags := tableOptions(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	table.Options = options
+	tflog.Debug(ctx, "Creating table", tableLogFields(table))
+	err := r.client.CreateTable(table)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create the table",
+			err.Error(),
+		)
+		return
+	}
+
+	// Populate computed attribute values
+	plan.ID = types.StringValue(tableID(table.Keyspace, table.Name))
+	resp.Diagnostics.Append(r.readTableOptions(ctx, &plan)...)
+
+	// Set state to fully populate data
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// The provider uses the `Read` method to retrieve the resource's information and update the state
+// The provider invokes this function before every plan.
+func (r *tableResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state tableResourceModel
+
+	// Read state.
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	table, err := r.client.GetTable(state.Keyspace.ValueString(), state.Name.ValueString())
+	if errors.Is(err, scylladb.ErrTableNotFound) {
+		// The table was dropped outside of Terraform, so let Terraform recreate it
+		tflog.Warn(ctx, "Table not found, removing it from the state", map[string]any{"table": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read the table",
+			err.Error(),
+		)
+		return
+	}
+
+	// Overwrite with refreshed state. The attributes that only exist in
+	// Terraform take their defaults when the table is imported.
+	refreshed := tableResourceModel{
+		ID:                 types.StringValue(tableID(table.Keyspace, table.Name)),
+		Keyspace:           types.StringValue(table.Keyspace),
+		Name:               types.StringValue(table.Name),
+		Columns:            tableColumnsValue(state.Columns, table.Columns),
+		DeletionProtection: state.DeletionProtection,
+		ForceDestroy:       state.ForceDestroy,
+	}
+	if refreshed.DeletionProtection.IsNull() {
+		refreshed.DeletionProtection = types.BoolValue(true)
+	}
+	if refreshed.ForceDestroy.IsNull() {
+		refreshed.ForceDestroy = types.BoolValue(false)
+	}
+	resp.Diagnostics.Append(setTableOptions(ctx, &refreshed, state, table.Options)...)
+	for _, name := range table.PartitionKey {
+		refreshed.PartitionKey = append(refreshed.PartitionKey, types.StringValue(name))
+	}
+	for _, column := range table.ClusteringKey {
+		refreshed.ClusteringKey = append(refreshed.ClusteringKey, tableClusteringColumnModel{
+			Name:  types.StringValue(column.Name),
+			Order: types.StringValue(column.Order()),
+		})
+	}
+
+	// Set state.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &refreshed)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// The provider uses the `Update` method to add and drop the columns that
+// are not part of the primary key and to set the options that changed.
+func (r *tableResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan and state
+	var plan, state tableResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Alter the table, setting only the options that changed
+	table := planToTable(plan)
+	options, diags := changedTableOptions(ctx, plan, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	table.Options = options
+	tflog.Debug(ctx, "Altering table", tableLogFields(table))
+	err := r.client.AlterTable(table)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to alter the table",
+			err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(r.readTableOptions(ctx, &plan)...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// The provider uses the `Delete` method to attempt to retrieve the values from state and delete the resource.
+func (r *tableResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state tableResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keyspace, name := state.Keyspace.ValueString(), state.Name.ValueString()
+	if !state.ForceDestroy.ValueBool() {
+		empty, err := r.client.IsTableEmpty(keyspace, name)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to check whether the table is empty",
+				err.Error(),
+			)
+			return
+		}
+		if !empty {
+			resp.Diagnostics.AddError(
+				"Table is not empty",
+				fmt.Sprintf("The table %q holds data. Apply force_destroy = true on the table to drop it anyway.", tableID(keyspace, name)),
+			)
+			return
+		}
+	}
+
+	// Drop the table
+	tflog.Debug(ctx, "Dropping table", map[string]any{"table": tableID(keyspace, name)})
+	err := r.client.DropTable(keyspace, name)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to drop the table",
+			err.Error(),
+		)
+		return
+	}
+}
+
+// The provider uses the `ModifyPlan` method to replace the table when a
+// column changes in a way ALTER TABLE cannot apply, to enforce the deletion
+// protection and to warn about changes to the CDC log table.
+func (r *tableResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing is dropped when creating
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state tableResourceModel
+	if !req.Plan.Raw.IsNull() {
+		resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	}
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !req.Plan.Raw.IsNull() && tableKnown(plan) && columnsRequireReplace(state.Columns, plan.Columns) {
+		resp.RequiresReplace.Append(path.Root("columns"))
+	}
+	replace, diags := planReplaces(ctx, req, resp, tableReplaceAttributes)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(checkDeletionProtection("table", state.ID.ValueString(), state.DeletionProtection, req.Plan.Raw.IsNull(), replace)...)
+	if resp.Diagnostics.HasError() || req.Plan.Raw.IsNull() || replace {
+		return
+	}
+
+	resp.Diagnostics.Append(cdcImageWarnings(ctx, plan, state)...)
+}
+
+// The provider users the `ImportState` method to import an existing table.
+// The ID is the keyspace and the table separated by a dot.
+func (r *tableResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	keyspace, name, err := parseTableID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid table ID",
+			err.Error(),
+		)
+		return
+	}
+
+	// Fail early with a clear message instead of importing an empty state
+	_, err = r.client.GetTable(keyspace, name)
+	if errors.Is(err, scylladb.ErrTableNotFound) {
+		resp.Diagnostics.AddError(
+			"Table not found",
+			fmt.Sprintf("Cannot import the table %q because it does not exist in the cluster.", req.ID),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read the table",
+			err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("keyspace"), keyspace)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+}
+
+// readTableOptions sets the options of the table model to the options of
+// the table, which fills those left to the cluster.
+func (r *tableResource) readTableOptions(ctx context.Context, model *tableResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	table, err := r.client.GetTable(model.Keyspace.ValueString(), model.Name.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Unable to read the table",
+			err.Error(),
+		)
+		return diags
+	}
+	return setTableOptions(ctx, model, *model, table.Options)
+}
+
+// planToTable converts the columns and the primary key of the table model
+// into a scylladb.Table. The options are converted by tableOptions.
+func planToTable(plan tableResourceModel) scylladb.Table {
+	table := scylladb.Table{
+		Keyspace: plan.Keyspace.ValueString(),
+		Name:     plan.Name.ValueString(),
+	}
+	for _, column := range plan.Columns {
+		table.Columns = append(table.Columns, scylladb.Column{
+			Name:   column.Name.ValueString(),
+			Type:   column.Type.ValueString(),
+			Static: column.Static.ValueBool(),
+		})
+	}
+	for _, name := range plan.PartitionKey {
+		table.PartitionKey = append(table.PartitionKey, name.ValueString())
+	}
+	for _, column := range plan.ClusteringKey {
+		table.ClusteringKey = append(table.ClusteringKey, scylladb.ClusteringColumn{
+			Name:       column.Name.ValueString(),
+			Descending: column.Order.ValueString() == scylladb.ClusteringOrderDesc,
+		})
+	}
+	return table
+}
+
+// tableKnown reports whether the columns and the primary key of the table
+// model are known.
+func tableKnown(model tableResourceModel) bool {
+	if model.Keyspace.IsUnknown() || model.Name.IsUnknown() {
+		return false
+	}
+	for _, column := range model.Columns {
+		if column.Name.IsUnknown() || column.Type.IsUnknown() || column.Static.IsUnknown() {
+			return false
+		}
+	}
+	for _, name := range model.PartitionKey {
+		if name.IsUnknown() {
+			return false
+		}
+	}
+	for _, column := range model.ClusteringKey {
+		if column.Name.IsUnknown() || column.Order.IsUnknown() {
+			return false
+		}
+	}
+	return true
+}
+
+// columnsRequireReplace reports whether a column of both lists changes its
+// type or whether it is static, which ALTER TABLE cannot apply.
+func columnsRequireReplace(current, desired []tableColumnModel) bool {
+	for _, column := range desired {
+		i := slices.IndexFunc(current, func(c tableColumnModel) bool { return c.Name.Equal(column.Name) })
+		if i < 0 {
+			continue
+		}
+		if !scylladb.EqualTypes(current[i].Type.ValueString(), column.Type.ValueString()) ||
+			current[i].Static.ValueBool() != column.Static.ValueBool() {
+			return true
+		}
+	}
+	return false
+}
+
+// tableColumnsValue converts the columns read from the cluster into the
+// columns list. The columns of the prior state keep their order and the
+// spelling of their type, and the other columns follow in the order of the
+// cluster.
+func tableColumnsValue(prior []tableColumnModel, columns []scylladb.Column) []tableColumnModel {
+	var result []tableColumnModel
+	seen := map[string]bool{}
+	for _, column := range prior {
+		i := slices.IndexFunc(columns, func(c scylladb.Column) bool { return c.Name == column.Name.ValueString() })
+		if i < 0 {
+			continue
+		}
+		result = append(result, tableColumnValue(columns[i], column.Type))
+		seen[columns[i].Name] = true
+	}
+	for _, column := range columns {
+		if !seen[column.Name] {
+			result = append(result, tableColumnValue(column, types.StringNull()))
+		}
+	}
+	return result
+}
+
+// tableColumnValue converts a column into a column model, keeping priorType
+// when it is the same type as the type of the column.
+func tableColumnValue(column scylladb.Column, priorType types.String) tableColumnModel {
+	columnType := types.StringValue(column.Type)
+	if !priorType.IsNull() && scylladb.EqualTypes(priorType.ValueString(), column.Type) {
+		columnType = priorType
+	}
+	return tableColumnModel{
+		Name:   types.StringValue(column.Name),
+		Type:   columnType,
+		Static: types.BoolValue(column.Static),
+	}
+}
+
+// tableID returns the ID of a table.
+func tableID(keyspace, name string) string {
+	return keyspace + tableIDSeparator + name
+}
+
+// parseTableID splits a table ID into the keyspace and the table.
+func parseTableID(id string) (keyspace, name string, err error) {
+	keyspace, name, ok := strings.Cut(id, tableIDSeparator)
+	if !ok || keyspace == "" || name == "" {
+		return "", "", fmt.Errorf("expected an ID of the form <keyspace>%s<table>, got %q", tableIDSeparator, id)
+	}
+	return keyspace, name, nil
+}
+
+// tableLogFields returns the fields of a table to log.
+func tableLogFields(table scylladb.Table) map[string]any {
+	return map[string]any{
+		"table":          tableID(table.Keyspace, table.Name),
+		"columns":        table.Columns,
+		"partition_key":  table.PartitionKey,
+		"clustering_key": table.ClusteringKey,
+	}
+}
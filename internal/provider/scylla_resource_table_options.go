@@ -0,0 +1,708 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/i1snow/terraform-provider-scylladb/scylladb"
+)
+
+// tableCompactionModel maps the compaction attribute.
+type tableCompactionModel struct {
+	Class   types.String `tfsdk:"class"`
+	Options types.Map    `tfsdk:"options"`
+}
+
+// tableCompactionAttrTypes are the attribute types of the compaction
+// attribute.
+var tableCompactionAttrTypes = map[string]attr.Type{
+	"class":   types.StringType,
+	"options": types.MapType{ElemType: types.StringType},
+}
+
+// tableCompressionModel maps the compression attribute.
+type tableCompressionModel struct {
+	Enabled         types.Bool   `tfsdk:"enabled"`
+	Class           types.String `tfsdk:"class"`
+	ChunkLengthInKB types.Int64  `tfsdk:"chunk_length_in_kb"`
+}
+
+// tableCompressionAttrTypes are the attribute types of the compression
+// attribute.
+var tableCompressionAttrTypes = map[string]attr.Type{
+	"enabled":            types.BoolType,
+	"class":              types.StringType,
+	"chunk_length_in_kb": types.Int64Type,
+}
+
+// tableCachingModel maps the caching attribute.
+type tableCachingModel struct {
+	Enabled          types.Bool   `tfsdk:"enabled"`
+	Keys             types.String `tfsdk:"keys"`
+	RowsPerPartition types.String `tfsdk:"rows_per_partition"`
+}
+
+// tableCachingAttrTypes are the attribute types of the caching attribute.
+var tableCachingAttrTypes = map[string]attr.Type{
+	"enabled":            types.BoolType,
+	"keys":               types.StringType,
+	"rows_per_partition": types.StringType,
+}
+
+// tableCDCModel maps the cdc attribute.
+type tableCDCModel struct {
+	Enabled   types.Bool   `tfsdk:"enabled"`
+	Preimage  types.String `tfsdk:"preimage"`
+	Postimage types.Bool   `tfsdk:"postimage"`
+	Delta     types.String `tfsdk:"delta"`
+	TTL       types.Int64  `tfsdk:"ttl"`
+}
+
+// tableCDCAttrTypes are the attribute types of the cdc attribute.
+var tableCDCAttrTypes = map[string]attr.Type{
+	"enabled":   types.BoolType,
+	"preimage":  types.StringType,
+	"postimage": types.BoolType,
+	"delta":     types.StringType,
+	"ttl":       types.Int64Type,
+}
+
+// tableTombstoneGCModel maps the tombstone_gc attribute.
+type tableTombstoneGCModel struct {
+	Mode             types.String `tfsdk:"mode"`
+	PropagationDelay types.Int64  `tfsdk:"propagation_delay_in_seconds"`
+}
+
+// tableTombstoneGCAttrTypes are the attribute types of the tombstone_gc
+// attribute.
+var tableTombstoneGCAttrTypes = map[string]attr.Type{
+	"mode":                         types.StringType,
+	"propagation_delay_in_seconds": types.Int64Type,
+}
+
+// tableRateLimitModel maps the per_partition_rate_limit attribute.
+type tableRateLimitModel struct {
+	MaxReadsPerSecond  types.Int64 `tfsdk:"max_reads_per_second"`
+	MaxWritesPerSecond types.Int64 `tfsdk:"max_writes_per_second"`
+}
+
+// tableRateLimitAttrTypes are the attribute types of the
+// per_partition_rate_limit attribute.
+var tableRateLimitAttrTypes = map[string]attr.Type{
+	"max_reads_per_second":  types.Int64Type,
+	"max_writes_per_second": types.Int64Type,
+}
+
+// tableOptionsAttributes returns the attributes of the table options. They
+// default to the values of the cluster, which are read back once the table
+// exists.
+func tableOptionsAttributes() map[string]schema.Attribute {
+	nonNegative := func(description string) schema.Int64Attribute {
+		return schema.Int64Attribute{
+			Description: description,
+			Optional:    true,
+			Computed:    true,
+			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.UseStateForUnknown(),
+			},
+			Validators: []validator.Int64{
+				int64validator.AtLeast(0),
+			},
+		}
+	}
+	return map[string]schema.Attribute{
+		"comment": schema.StringAttribute{
+			Description: "A free-form description of the table.",
+			Optional:    true,
+			Computed:    true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"default_time_to_live": nonNegative("The time to live in seconds of the rows written without one. `0` disables expiration."),
+		"gc_grace_seconds":     nonNegative("How long in seconds tombstones are kept before being garbage collected."),
+		"paxos_grace_seconds":  nonNegative("How long in seconds the Paxos state of lightweight transactions is kept."),
+		"memtable_flush_period_in_ms": nonNegative(
+			"How often in milliseconds the memtable is flushed to disk. `0` only flushes it when it is full."),
+		"bloom_filter_fp_chance": schema.Float64Attribute{
+			Description: "The false positive probability of the bloom filter of the SSTables, greater than `0` and at most `1`.",
+			Optional:    true,
+			Computed:    true,
+			PlanModifiers: []planmodifier.Float64{
+				float64planmodifier.UseStateForUnknown(),
+			},
+		},
+		"speculative_retry": schema.StringAttribute{
+			Description: "When a read is retried on another replica: `NONE`, `ALWAYS`, a percentile of the latency such as `99PERCENTILE`, or a latency such as `50ms`.",
+			Optional:    true,
+			Computed:    true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"compaction": schema.SingleNestedAttribute{
+			Description: "The compaction strategy of the table and its options.",
+			Optional:    true,
+			Computed:    true,
+			PlanModifiers: []planmodifier.Object{
+				objectplanmodifier.UseStateForUnknown(),
+			},
+			Attributes: map[string]schema.Attribute{
+				"class": schema.StringAttribute{
+					Description: "The compaction strategy: `" + strings.Join(scylladb.CompactionStrategies, "`, `") + "`.",
+					Required:    true,
+				},
+				"options": schema.MapAttribute{
+					Description: "The options of the compaction strategy, such as `sstable_size_in_mb` for `LeveledCompactionStrategy`. " +
+						"Each strategy only accepts its own options. Only the options given here are tracked, the others keep the values of the cluster.",
+					Optional:    true,
+					ElementType: types.StringType,
+				},
+			},
+		},
+		"compression": schema.SingleNestedAttribute{
+			Description: "The compression of the SSTables of the table.",
+			Optional:    true,
+			Computed:    true,
+			PlanModifiers: []planmodifier.Object{
+				objectplanmodifier.UseStateForUnknown(),
+			},
+			Attributes: map[string]schema.Attribute{
+				"enabled": schema.BoolAttribute{
+					Description: "Whether the SSTables are compressed.",
+					Optional:    true,
+					Computed:    true,
+					PlanModifiers: []planmodifier.Bool{
+						boolplanmodifier.UseStateForUnknown(),
+					},
+				},
+				"class": schema.StringAttribute{
+					Description: "The compression algorithm: `" + strings.Join(scylladb.CompressionAlgorithms, "`, `") + "`.",
+					Optional:    true,
+					Computed:    true,
+					PlanModifiers: []planmodifier.String{
+						stringplanmodifier.UseStateForUnknown(),
+					},
+				},
+				"chunk_length_in_kb": schema.Int64Attribute{
+					Description: "The size in kilobytes of the compressed chunks, a power of two. Defaults to the configuration of the cluster.",
+					Optional:    true,
+					Computed:    true,
+					PlanModifiers: []planmodifier.Int64{
+						int64planmodifier.UseStateForUnknown(),
+					},
+					Validators: []validator.Int64{
+						int64validator.AtLeast(1),
+					},
+				},
+			},
+		},
+		"caching": schema.SingleNestedAttribute{
+			Description: "The caching of the table.",
+			Optional:    true,
+			Computed:    true,
+			PlanModifiers: []planmodifier.Object{
+				objectplanmodifier.UseStateForUnknown(),
+			},
+			Attributes: map[string]schema.Attribute{
+				"enabled": schema.BoolAttribute{
+					Description: "Whether the table is cached.",
+					Optional:    true,
+					Computed:    true,
+					PlanModifiers: []planmodifier.Bool{
+						boolplanmodifier.UseStateForUnknown(),
+					},
+				},
+				"keys": schema.StringAttribute{
+					Description: "Which partition keys are cached, `ALL` or `NONE`.",
+					Optional:    true,
+					Computed:    true,
+					PlanModifiers: []planmodifier.String{
+						stringplanmodifier.UseStateForUnknown(),
+					},
+					Validators: []validator.String{
+						stringvalidator.OneOf("ALL", "NONE"),
+					},
+				},
+				"rows_per_partition": schema.StringAttribute{
+					Description: "How many rows of each partition are cached: `ALL`, `NONE` or a number of rows.",
+					Optional:    true,
+					Computed:    true,
+					PlanModifiers: []planmodifier.String{
+						stringplanmodifier.UseStateForUnknown(),
+					},
+				},
+			},
+		},
+		"cdc": schema.SingleNestedAttribute{
+			Description: "The change data capture of the table. Changes are written to the `<name>_scylla_cdc_log` table, " +
+				"which is created when CDC is enabled. Keyspaces using tablets only support CDC on recent releases.",
+			Optional: true,
+			Computed: true,
+			PlanModifiers: []planmodifier.Object{
+				objectplanmodifier.UseStateForUnknown(),
+			},
+			Attributes: map[string]schema.Attribute{
+				"enabled": schema.BoolAttribute{
+					Description: "Whether changes to the table are logged.",
+					Optional:    true,
+					Computed:    true,
+					PlanModifiers: []planmodifier.Bool{
+						boolplanmodifier.UseStateForUnknown(),
+					},
+				},
+				"preimage": schema.StringAttribute{
+					Description: "Whether the log holds the state of the rows before each change: `false`, `true` for the changed columns, or `full` for every column.",
+					Optional:    true,
+					Computed:    true,
+					PlanModifiers: []planmodifier.String{
+						stringplanmodifier.UseStateForUnknown(),
+					},
+					Validators: []validator.String{
+						stringvalidator.OneOf(scylladb.CDCPreimageModes...),
+					},
+				},
+				"postimage": schema.BoolAttribute{
+					Description: "Whether the log holds the state of the rows after each change.",
+					Optional:    true,
+					Computed:    true,
+					PlanModifiers: []planmodifier.Bool{
+						boolplanmodifier.UseStateForUnknown(),
+					},
+				},
+				"delta": schema.StringAttribute{
+					Description: "What the log holds of each change: `full` for the changed columns, or `keys` for the primary key only.",
+					Optional:    true,
+					Computed:    true,
+					PlanModifiers: []planmodifier.String{
+						stringplanmodifier.UseStateForUnknown(),
+					},
+					Validators: []validator.String{
+						stringvalidator.OneOf(scylladb.CDCDeltaModes...),
+					},
+				},
+				"ttl": schema.Int64Attribute{
+					Description: "The time to live in seconds of the rows of the log. `0` keeps them forever.",
+					Optional:    true,
+					Computed:    true,
+					PlanModifiers: []planmodifier.Int64{
+						int64planmodifier.UseStateForUnknown(),
+					},
+					Validators: []validator.Int64{
+						int64validator.AtLeast(0),
+					},
+				},
+			},
+		},
+		"tombstone_gc": schema.SingleNestedAttribute{
+			Description: "When the tombstones of the table are garbage collected. Defaults to the configuration of the cluster, " +
+				"which depends on the keyspace, and is null when the table does not report it.",
+			Optional: true,
+			Computed: true,
+			PlanModifiers: []planmodifier.Object{
+				objectplanmodifier.UseStateForUnknown(),
+			},
+			Attributes: map[string]schema.Attribute{
+				"mode": schema.StringAttribute{
+					Description: "`timeout` drops tombstones after `gc_grace_seconds`, `repair` once a repair covered them, " +
+						"`immediate` as soon as possible and `disabled` never. Scylla refuses `repair` for keyspaces with a single replica.",
+					Required: true,
+					Validators: []validator.String{
+						stringvalidator.OneOf(scylladb.TombstoneGCModes...),
+					},
+				},
+				"propagation_delay_in_seconds": schema.Int64Attribute{
+					Description: "How long in seconds tombstones are kept after the repair that covered them in `repair` mode.",
+					Optional:    true,
+					Computed:    true,
+					PlanModifiers: []planmodifier.Int64{
+						int64planmodifier.UseStateForUnknown(),
+					},
+					Validators: []validator.Int64{
+						int64validator.AtLeast(0),
+					},
+				},
+			},
+		},
+		"per_partition_rate_limit": schema.SingleNestedAttribute{
+			Description: "Limits the operations on each partition of the table. The operations above the limits are rejected.",
+			Optional:    true,
+			Computed:    true,
+			PlanModifiers: []planmodifier.Object{
+				objectplanmodifier.UseStateForUnknown(),
+			},
+			Attributes: map[string]schema.Attribute{
+				"max_reads_per_second": schema.Int64Attribute{
+					Description: "The maximum number of reads per second of a partition. Not limited when null.",
+					Optional:    true,
+					Validators: []validator.Int64{
+						int64validator.AtLeast(1),
+					},
+				},
+				"max_writes_per_second": schema.Int64Attribute{
+					Description: "The maximum number of writes per second of a partition. Not limited when null.",
+					Optional:    true,
+					Validators: []validator.Int64{
+						int64validator.AtLeast(1),
+					},
+				},
+			},
+		},
+	}
+}
+
+// tableOptions converts the options of the table model into
+// scylladb.TableOptions. Unknown and null options are left to the cluster.
+func tableOptions(ctx context.Context, model tableResourceModel) (scylladb.TableOptions, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var options scylladb.TableOptions
+	if known(model.Comment) {
+		options.Comment = model.Comment.ValueStringPointer()
+	}
+	options.DefaultTimeToLive = intPointer(model.DefaultTimeToLive)
+	options.GCGraceSeconds = intPointer(model.GCGraceSeconds)
+	options.PaxosGraceSeconds = intPointer(model.PaxosGraceSeconds)
+	options.MemtableFlushPeriodInMs = intPointer(model.MemtableFlushPeriodInMs)
+	if known(model.BloomFilterFPChance) {
+		options.BloomFilterFPChance = model.BloomFilterFPChance.ValueFloat64Pointer()
+	}
+	if known(model.SpeculativeRetry) {
+		options.SpeculativeRetry = model.SpeculativeRetry.ValueStringPointer()
+	}
+	asOptions := basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true}
+	if known(model.Compaction) {
+		var compaction tableCompactionModel
+		diags.Append(model.Compaction.As(ctx, &compaction, asOptions)...)
+		options.Compaction = &scylladb.Compaction{Class: compaction.Class.ValueString()}
+		if known(compaction.Options) {
+			diags.Append(compaction.Options.ElementsAs(ctx, &options.Compaction.Options, false)...)
+		}
+	}
+	if known(model.Compression) {
+		var compression tableCompressionModel
+		diags.Append(model.Compression.As(ctx, &compression, asOptions)...)
+		options.Compression = &scylladb.Compression{
+			Disabled:        known(compression.Enabled) && !compression.Enabled.ValueBool(),
+			Class:           compression.Class.ValueString(),
+			ChunkLengthInKB: intPointer(compression.ChunkLengthInKB),
+		}
+	}
+	if known(model.Caching) {
+		var caching tableCachingModel
+		diags.Append(model.Caching.As(ctx, &caching, asOptions)...)
+		options.Caching = &scylladb.Caching{
+			Disabled:         known(caching.Enabled) && !caching.Enabled.ValueBool(),
+			Keys:             caching.Keys.ValueString(),
+			RowsPerPartition: caching.RowsPerPartition.ValueString(),
+		}
+	}
+	if known(model.CDC) {
+		var cdc tableCDCModel
+		diags.Append(model.CDC.As(ctx, &cdc, asOptions)...)
+		// CDC is enabled when only its options are given
+		options.CDC = &scylladb.CDC{
+			Enabled:   !known(cdc.Enabled) || cdc.Enabled.ValueBool(),
+			Preimage:  cdc.Preimage.ValueString(),
+			Postimage: cdc.Postimage.ValueBool(),
+			Delta:     cdc.Delta.ValueString(),
+			TTL:       intPointer(cdc.TTL),
+		}
+	}
+	if known(model.TombstoneGC) {
+		var tombstoneGC tableTombstoneGCModel
+		diags.Append(model.TombstoneGC.As(ctx, &tombstoneGC, asOptions)...)
+		// The mode is required, but unknown until apply when it comes from another resource
+		if !tombstoneGC.Mode.IsUnknown() {
+			options.TombstoneGC = &scylladb.TombstoneGC{
+				Mode:             tombstoneGC.Mode.ValueString(),
+				PropagationDelay: intPointer(tombstoneGC.PropagationDelay),
+			}
+		}
+	}
+	if known(model.PerPartitionRateLimit) {
+		var limit tableRateLimitModel
+		diags.Append(model.PerPartitionRateLimit.As(ctx, &limit, asOptions)...)
+		options.PerPartitionRateLimit = &scylladb.PerPartitionRateLimit{
+			MaxReadsPerSecond:  intPointer(limit.MaxReadsPerSecond),
+			MaxWritesPerSecond: intPointer(limit.MaxWritesPerSecond),
+		}
+	}
+	return options, diags
+}
+
+// changedTableOptions returns the options of plan that differ from state,
+// which is what ALTER TABLE has to set.
+func changedTableOptions(ctx context.Context, plan, state tableResourceModel) (scylladb.TableOptions, diag.Diagnostics) {
+	options, diags := tableOptions(ctx, plan)
+	if plan.Comment.Equal(state.Comment) {
+		options.Comment = nil
+	}
+	if plan.DefaultTimeToLive.Equal(state.DefaultTimeToLive) {
+		options.DefaultTimeToLive = nil
+	}
+	if plan.GCGraceSeconds.Equal(state.GCGraceSeconds) {
+		options.GCGraceSeconds = nil
+	}
+	if plan.PaxosGraceSeconds.Equal(state.PaxosGraceSeconds) {
+		options.PaxosGraceSeconds = nil
+	}
+	if plan.MemtableFlushPeriodInMs.Equal(state.MemtableFlushPeriodInMs) {
+		options.MemtableFlushPeriodInMs = nil
+	}
+	if plan.BloomFilterFPChance.Equal(state.BloomFilterFPChance) {
+		options.BloomFilterFPChance = nil
+	}
+	if plan.SpeculativeRetry.Equal(state.SpeculativeRetry) {
+		options.SpeculativeRetry = nil
+	}
+	if plan.Compaction.Equal(state.Compaction) {
+		options.Compaction = nil
+	}
+	if plan.Compression.Equal(state.Compression) {
+		options.Compression = nil
+	}
+	if plan.Caching.Equal(state.Caching) {
+		options.Caching = nil
+	}
+	if plan.CDC.Equal(state.CDC) {
+		options.CDC = nil
+	}
+	if plan.TombstoneGC.Equal(state.TombstoneGC) {
+		options.TombstoneGC = nil
+	}
+	if plan.PerPartitionRateLimit.Equal(state.PerPartitionRateLimit) {
+		options.PerPartitionRateLimit = nil
+	}
+	return options, diags
+}
+
+// setTableOptions sets the options of the model to the options read from the
+// cluster. The options of prior keep their spelling when the cluster reports
+// them in another form, and only the compaction options of prior are kept.
+func setTableOptions(ctx context.Context, model *tableResourceModel, prior tableResourceModel, options scylladb.TableOptions) diag.Diagnostics {
+	var diags diag.Diagnostics
+	model.Comment = types.StringPointerValue(options.Comment)
+	model.DefaultTimeToLive = int64Value(options.DefaultTimeToLive)
+	model.GCGraceSeconds = int64Value(options.GCGraceSeconds)
+	model.PaxosGraceSeconds = int64Value(options.PaxosGraceSeconds)
+	model.MemtableFlushPeriodInMs = int64Value(options.MemtableFlushPeriodInMs)
+	model.BloomFilterFPChance = types.Float64PointerValue(options.BloomFilterFPChance)
+	model.SpeculativeRetry = types.StringPointerValue(options.SpeculativeRetry)
+	if known(prior.SpeculativeRetry) && options.SpeculativeRetry != nil &&
+		scylladb.EqualSpeculativeRetry(prior.SpeculativeRetry.ValueString(), *options.SpeculativeRetry) {
+		model.SpeculativeRetry = prior.SpeculativeRetry
+	}
+
+	asOptions := basetypes.ObjectAsOptions{UnhandledNullAsEmpty: true, UnhandledUnknownAsEmpty: true}
+	if options.Compaction != nil {
+		var priorCompaction tableCompactionModel
+		if !prior.Compaction.IsUnknown() {
+			diags.Append(prior.Compaction.As(ctx, &priorCompaction, asOptions)...)
+		}
+		compactionOptions := types.MapNull(types.StringType)
+		switch {
+		case known(priorCompaction.Options):
+			// Only the options that were given are tracked
+			elements := map[string]attr.Value{}
+			for name, value := range priorCompaction.Options.Elements() {
+				current, ok := options.Compaction.Options[name]
+				if !ok {
+					continue
+				}
+				elements[name] = types.StringValue(current)
+				if v, ok := value.(types.String); ok && strings.EqualFold(v.ValueString(), current) {
+					elements[name] = v
+				}
+			}
+			compactionOptions = types.MapValueMust(types.StringType, elements)
+		case known(prior.Compaction) || len(options.Compaction.Options) == 0:
+			// The options were left to the cluster
+		default:
+			// Imported or created without compaction, so every option is tracked
+			compactionOptions, _ = types.MapValueFrom(ctx, types.StringType, options.Compaction.Options)
+		}
+		model.Compaction = types.ObjectValueMust(tableCompactionAttrTypes, map[string]attr.Value{
+			"class":   keepSpelling(priorCompaction.Class, options.Compaction.Class, scylladb.NormalizeCompactionClass),
+			"options": compactionOptions,
+		})
+	}
+
+	if options.Compression != nil {
+		var priorCompression tableCompressionModel
+		if !prior.Compression.IsUnknown() {
+			diags.Append(prior.Compression.As(ctx, &priorCompression, asOptions)...)
+		}
+		class := keepSpelling(priorCompression.Class, options.Compression.Class, scylladb.NormalizeCompressionClass)
+		chunkLength := int64Value(options.Compression.ChunkLengthInKB)
+		if options.Compression.Disabled {
+			// Disabled compression has neither algorithm nor chunk length
+			class = valueOr(priorCompression.Class, types.StringValue(""))
+			chunkLength = valueOr(priorCompression.ChunkLengthInKB, types.Int64Null())
+		}
+		model.Compression = types.ObjectValueMust(tableCompressionAttrTypes, map[string]attr.Value{
+			"enabled":            types.BoolValue(!options.Compression.Disabled),
+			"class":              class,
+			"chunk_length_in_kb": chunkLength,
+		})
+	}
+
+	if options.Caching != nil {
+		var priorCaching tableCachingModel
+		if !prior.Caching.IsUnknown() {
+			diags.Append(prior.Caching.As(ctx, &priorCaching, asOptions)...)
+		}
+		keys := types.StringValue(options.Caching.Keys)
+		if options.Caching.Keys == "" {
+			keys = valueOr(priorCaching.Keys, keys)
+		}
+		rows := types.StringValue(options.Caching.RowsPerPartition)
+		if options.Caching.RowsPerPartition == "" {
+			rows = valueOr(priorCaching.RowsPerPartition, rows)
+		}
+		model.Caching = types.ObjectValueMust(tableCachingAttrTypes, map[string]attr.Value{
+			"enabled":            types.BoolValue(!options.Caching.Disabled),
+			"keys":               keys,
+			"rows_per_partition": rows,
+		})
+	}
+
+	if options.CDC != nil {
+		var priorCDC tableCDCModel
+		if !prior.CDC.IsUnknown() {
+			diags.Append(prior.CDC.As(ctx, &priorCDC, asOptions)...)
+		}
+		preimage := types.StringValue(options.CDC.Preimage)
+		postimage := types.BoolValue(options.CDC.Postimage)
+		delta := types.StringValue(options.CDC.Delta)
+		ttl := int64Value(options.CDC.TTL)
+		if known(priorCDC.Preimage) && strings.EqualFold(priorCDC.Preimage.ValueString(), options.CDC.Preimage) {
+			preimage = priorCDC.Preimage
+		}
+		if !options.CDC.Enabled {
+			// The options of disabled CDC are only applied when it is enabled
+			preimage = valueOr(priorCDC.Preimage, preimage)
+			postimage = valueOr(priorCDC.Postimage, postimage)
+			delta = valueOr(priorCDC.Delta, delta)
+			ttl = valueOr(priorCDC.TTL, ttl)
+		}
+		model.CDC = types.ObjectValueMust(tableCDCAttrTypes, map[string]attr.Value{
+			"enabled":   types.BoolValue(options.CDC.Enabled),
+			"preimage":  preimage,
+			"postimage": postimage,
+			"delta":     delta,
+			"ttl":       ttl,
+		})
+	}
+
+	// Tables that do not report their garbage collection of tombstones leave
+	// it null rather than assuming the default of the cluster
+	model.TombstoneGC = types.ObjectNull(tableTombstoneGCAttrTypes)
+	if options.TombstoneGC != nil {
+		model.TombstoneGC = types.ObjectValueMust(tableTombstoneGCAttrTypes, map[string]attr.Value{
+			"mode":                         types.StringValue(options.TombstoneGC.Mode),
+			"propagation_delay_in_seconds": int64Value(options.TombstoneGC.PropagationDelay),
+		})
+	}
+	if options.PerPartitionRateLimit != nil {
+		model.PerPartitionRateLimit = types.ObjectValueMust(tableRateLimitAttrTypes, map[string]attr.Value{
+			"max_reads_per_second":  int64Value(options.PerPartitionRateLimit.MaxReadsPerSecond),
+			"max_writes_per_second": int64Value(options.PerPartitionRateLimit.MaxWritesPerSecond),
+		})
+	}
+	return diags
+}
+
+// cdcImageWarnings warns when the plan changes the preimage or the postimage
+// of a table with CDC enabled, which changes the rows written to its CDC log
+// table and makes every write read the row first.
+func cdcImageWarnings(ctx context.Context, plan, state tableResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if !known(plan.CDC) || !known(state.CDC) {
+		return diags
+	}
+	var planCDC, stateCDC tableCDCModel
+	asOptions := basetypes.ObjectAsOptions{UnhandledNullAsEmpty: true, UnhandledUnknownAsEmpty: true}
+	diags.Append(plan.CDC.As(ctx, &planCDC, asOptions)...)
+	diags.Append(state.CDC.As(ctx, &stateCDC, asOptions)...)
+	if diags.HasError() || !planCDC.Enabled.ValueBool() {
+		return diags
+	}
+
+	var changed []string
+	if known(planCDC.Preimage) && !strings.EqualFold(planCDC.Preimage.ValueString(), stateCDC.Preimage.ValueString()) {
+		changed = append(changed, "preimage")
+	}
+	if known(planCDC.Postimage) && !planCDC.Postimage.Equal(stateCDC.Postimage) {
+		changed = append(changed, "postimage")
+	}
+	if len(changed) == 0 {
+		return diags
+	}
+	logTable := tableID(plan.Keyspace.ValueString(), scylladb.CDCLogTable(plan.Name.ValueString()))
+	diags.AddWarning(
+		"CDC log table changes",
+		fmt.Sprintf("Changing the cdc %s of the table %q changes the rows written to its CDC log table %q. "+
+			"Only the changes made after the update are logged the new way, so consumers of the log must handle both. "+
+			"Preimages and postimages make every write to the table read the row first.",
+			strings.Join(changed, " and "), plan.ID.ValueString(), logTable),
+	)
+	return diags
+}
+
+// keepSpelling returns prior when it names the same class as current once
+// both are normalized, and current otherwise.
+func keepSpelling(prior types.String, current string, normalize func(string) string) types.String {
+	if known(prior) && normalize(prior.ValueString()) == normalize(current) {
+		return prior
+	}
+	return types.StringValue(current)
+}
+
+// valueOr returns value when it is known and fallback otherwise.
+func valueOr[T attr.Value](value, fallback T) T {
+	if known(value) {
+		return value
+	}
+	return fallback
+}
+
+// known reports whether a value is neither null nor unknown.
+func known(value attr.Value) bool {
+	return !value.IsNull() && !value.IsUnknown()
+}
+
+// intPointer converts a known number into an int pointer, and unknown and
+// null numbers into nil.
+func intPointer(value types.Int64) *int {
+	if !known(value) {
+		return nil
+	}
+	i := int(value.ValueInt64())
+	return &i
+}
+
+// int64Value converts an int pointer into a number, nil being null.
+func int64Value(value *int) types.Int64 {
+	if value == nil {
+		return types.Int64Null()
+	}
+	return types.Int64Value(int64(*value))
+}
@@ -0,0 +1,474 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/i1snow/terraform-provider-scylladb/internal/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+const tableKeyspaceConfig = `
+resource "scylladb_keyspace" "app" {
+  name                = "app_ks"
+  deletion_protection = false
+  force_destroy       = true
+  datacenters = {
+    datacenter1 = 1
+  }
+}
+`
+
+func TestAccTableResource(t *testing.T) {
+	devClusterHost := testutil.NewTestContainer(t)
+	providerConfig := fmt.Sprintf(providerConfigFmt, devClusterHost) + tableKeyspaceConfig
+	tableConfig := providerConfig + `
+resource "scylladb_table" "events" {
+  keyspace            = scylladb_keyspace.app.name
+  name                = "events"
+  deletion_protection = false
+
+  columns = [
+    { name = "tenant", type = "text" },
+    { name = "day", type = "date" },
+    { name = "ts", type = "timeuuid" },
+    %s
+  ]
+
+  partition_key = ["tenant", "day"]
+  clustering_key = [
+    { name = "ts", order = "DESC" },
+  ]
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// A primary key column that is not a column fails at plan time
+			{
+				Config: providerConfig + `
+resource "scylladb_table" "invalid" {
+  keyspace      = "app_ks"
+  name          = "invalid"
+  columns       = [{ name = "id", type = "int" }]
+  partition_key = ["missing"]
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("primary key column missing is not a column of the table"),
+			},
+			// An invalid type fails at plan time on its column
+			{
+				Config: providerConfig + `
+resource "scylladb_table" "invalid" {
+  keyspace      = "app_ks"
+  name          = "invalid"
+  columns       = [{ name = "id", type = "int" }, { name = "tags", type = "list<set<text>>" }]
+  partition_key = ["id"]
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`(?s)Invalid column type.*Column tags`),
+			},
+			// Create and Read testing
+			{
+				Config: fmt.Sprintf(tableConfig, `{ name = "payload", type = "frozen<map<text, list<text>>>" },
+    { name = "tenant_name", type = "TEXT", static = true },`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("scylladb_table.events", "id", "app_ks.events"),
+					resource.TestCheckResourceAttr("scylladb_table.events", "columns.#", "5"),
+					// The cluster reports frozen<map<text, frozen<list<text>>>>
+					resource.TestCheckResourceAttr("scylladb_table.events", "columns.3.type", "frozen<map<text, list<text>>>"),
+					resource.TestCheckResourceAttr("scylladb_table.events", "columns.3.static", "false"),
+					resource.TestCheckResourceAttr("scylladb_table.events", "columns.4.type", "TEXT"),
+					resource.TestCheckResourceAttr("scylladb_table.events", "columns.4.static", "true"),
+					resource.TestCheckResourceAttr("scylladb_table.events", "clustering_key.0.order", "DESC"),
+				),
+			},
+			// ImportState testing. Imported types are spelled as the cluster
+			// reports them and the other columns come sorted by name.
+			{
+				ResourceName:            "scylladb_table.events",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"columns", "deletion_protection"},
+			},
+			// Non-key columns are added and dropped in place
+			{
+				Config: fmt.Sprintf(tableConfig, `{ name = "source", type = "inet" },`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("scylladb_table.events", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("scylladb_table.events", "columns.#", "4"),
+					resource.TestCheckResourceAttr("scylladb_table.events", "columns.3.name", "source"),
+				),
+			},
+			// Changing the type of a column replaces the table
+			{
+				Config: fmt.Sprintf(tableConfig, `{ name = "source", type = "text" },`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("scylladb_table.events", plancheck.ResourceActionReplace),
+					},
+				},
+			},
+			// Importing a missing table fails with a clear error
+			{
+				ResourceName:  "scylladb_table.events",
+				ImportState:   true,
+				ImportStateId: "app_ks.missing",
+				ExpectError:   regexp.MustCompile("Table not found"),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccTableResourceDeletionProtection(t *testing.T) {
+	devClusterHost := testutil.NewTestContainer(t)
+	providerConfig := fmt.Sprintf(providerConfigFmt, devClusterHost) + tableKeyspaceConfig
+	tableConfig := providerConfig + `
+resource "scylladb_table" "orders" {
+  keyspace            = scylladb_keyspace.app.name
+  name                = "orders"
+  deletion_protection = %t
+  force_destroy       = %t
+  columns             = [{ name = "id", type = "int" }]
+  partition_key       = ["id"]
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "scylladb_table" "orders" {
+  keyspace      = scylladb_keyspace.app.name
+  name          = "orders"
+  columns       = [{ name = "id", type = "int" }]
+  partition_key = ["id"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("scylladb_table.orders", "deletion_protection", "true"),
+					resource.TestCheckResourceAttr("scylladb_table.orders", "force_destroy", "false"),
+				),
+			},
+			// A protected table cannot be destroyed
+			{
+				Config:      providerConfig,
+				ExpectError: regexp.MustCompile("The table is protected against deletion"),
+			},
+			// Nor replaced, whether by a plan modifier or by a column change
+			{
+				Config: providerConfig + `
+resource "scylladb_table" "orders" {
+  keyspace      = scylladb_keyspace.app.name
+  name          = "orders_v2"
+  columns       = [{ name = "id", type = "int" }]
+  partition_key = ["id"]
+}
+`,
+				ExpectError: regexp.MustCompile("The plan would replace the table"),
+			},
+			{
+				Config: providerConfig + `
+resource "scylladb_table" "orders" {
+  keyspace      = scylladb_keyspace.app.name
+  name          = "orders"
+  columns       = [{ name = "id", type = "bigint" }]
+  partition_key = ["id"]
+}
+`,
+				ExpectError: regexp.MustCompile("The plan would replace the table"),
+			},
+			// A table with data is not dropped without force_destroy
+			{
+				PreConfig: func() {
+					cluster := testAccClient(t, devClusterHost)
+					if err := cluster.Session.Query(`INSERT INTO "app_ks"."orders" (id) VALUES (1)`).Exec(); err != nil {
+						t.Fatalf("failed to write to the table: %s", err)
+					}
+				},
+				Config: fmt.Sprintf(tableConfig, false, false),
+			},
+			{
+				Config:      providerConfig,
+				ExpectError: regexp.MustCompile("Table is not empty"),
+			},
+			{
+				Config: fmt.Sprintf(tableConfig, false, true),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestCheckDeletionProtection(t *testing.T) {
+	protected, unprotected := types.BoolValue(true), types.BoolValue(false)
+	assert.False(t, checkDeletionProtection("table", "app_ks.orders", protected, false, false).HasError())
+	assert.False(t, checkDeletionProtection("table", "app_ks.orders", unprotected, true, true).HasError())
+
+	diags := checkDeletionProtection("table", "app_ks.orders", protected, true, false)
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Detail(), `The plan would destroy the table "app_ks.orders"`)
+
+	diags = checkDeletionProtection("table", "app_ks.orders", protected, false, true)
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Detail(), `The plan would replace the table "app_ks.orders"`)
+}
+
+func TestAccTableResourceOptions(t *testing.T) {
+	devClusterHost := testutil.NewTestContainer(t)
+	providerConfig := fmt.Sprintf(providerConfigFmt, devClusterHost) + tableKeyspaceConfig
+	tableConfig := providerConfig + `
+resource "scylladb_table" "metrics" {
+  keyspace            = scylladb_keyspace.app.name
+  name                = "metrics"
+  deletion_protection = false
+  columns             = [{ name = "id", type = "int" }, { name = "value", type = "double" }]
+  partition_key       = ["id"]
+
+  comment              = "Raw metrics"
+  default_time_to_live = %d
+  speculative_retry    = "99percentile"
+  compaction = {
+    class   = "%s"
+    options = %s
+  }
+  compression = {
+    class              = "ZstdCompressor"
+    chunk_length_in_kb = 16
+  }
+  caching = {
+    keys               = "ALL"
+    rows_per_partition = "NONE"
+  }
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Each compaction strategy only accepts its own options
+			{
+				Config:      fmt.Sprintf(tableConfig, 0, "LeveledCompactionStrategy", `{ min_threshold = "4" }`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("LeveledCompactionStrategy does not accept the option min_threshold"),
+			},
+			// Create and Read testing
+			{
+				Config: fmt.Sprintf(tableConfig, 86400, "TimeWindowCompactionStrategy", `{ compaction_window_unit = "DAYS", compaction_window_size = "1" }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("scylladb_table.metrics", "comment", "Raw metrics"),
+					resource.TestCheckResourceAttr("scylladb_table.metrics", "default_time_to_live", "86400"),
+					resource.TestCheckResourceAttr("scylladb_table.metrics", "speculative_retry", "99percentile"),
+					resource.TestCheckResourceAttr("scylladb_table.metrics", "compaction.options.%", "2"),
+					resource.TestCheckResourceAttr("scylladb_table.metrics", "compression.enabled", "true"),
+					resource.TestCheckResourceAttr("scylladb_table.metrics", "caching.enabled", "true"),
+					// Options left to the cluster are read back
+					resource.TestCheckResourceAttrSet("scylladb_table.metrics", "gc_grace_seconds"),
+					resource.TestCheckResourceAttrSet("scylladb_table.metrics", "bloom_filter_fp_chance"),
+				),
+			},
+			// Options are altered in place
+			{
+				Config: fmt.Sprintf(tableConfig, 3600, "LeveledCompactionStrategy", `{ sstable_size_in_mb = "128" }`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("scylladb_table.metrics", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("scylladb_table.metrics", "default_time_to_live", "3600"),
+					resource.TestCheckResourceAttr("scylladb_table.metrics", "compaction.class", "LeveledCompactionStrategy"),
+					resource.TestCheckResourceAttr("scylladb_table.metrics", "compaction.options.sstable_size_in_mb", "128"),
+				),
+			},
+			// ImportState testing. Every compaction option of the cluster is
+			// imported.
+			{
+				ResourceName:            "scylladb_table.metrics",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"deletion_protection", "speculative_retry", "compaction.options"},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccTableResourceCDC(t *testing.T) {
+	devClusterHost := testutil.NewTestContainer(t)
+	// CDC requires vnodes on the releases that do not support it with tablets
+	providerConfig := fmt.Sprintf(providerConfigFmt, devClusterHost) + `
+resource "scylladb_keyspace" "cdc" {
+  name                = "cdc_ks"
+  deletion_protection = false
+  force_destroy       = true
+  datacenters = {
+    datacenter1 = 1
+  }
+  tablets = {
+    enabled = false
+  }
+}
+`
+	tableConfig := providerConfig + `
+resource "scylladb_table" "orders" {
+  keyspace            = scylladb_keyspace.cdc.name
+  name                = "orders"
+  deletion_protection = false
+  columns             = [{ name = "id", type = "int" }, { name = "status", type = "text" }]
+  partition_key       = ["id"]
+  cdc = {
+    %s
+  }
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: fmt.Sprintf(tableConfig, `enabled = true
+    preimage = "full"
+    ttl      = 3600`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("scylladb_table.orders", "cdc.enabled", "true"),
+					resource.TestCheckResourceAttr("scylladb_table.orders", "cdc.preimage", "full"),
+					resource.TestCheckResourceAttr("scylladb_table.orders", "cdc.postimage", "false"),
+					resource.TestCheckResourceAttr("scylladb_table.orders", "cdc.delta", "full"),
+					resource.TestCheckResourceAttr("scylladb_table.orders", "cdc.ttl", "3600"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "scylladb_table.orders",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"deletion_protection"},
+			},
+			// The images are changed in place
+			{
+				Config: fmt.Sprintf(tableConfig, `enabled   = true
+    preimage  = "false"
+    postimage = true
+    ttl       = 3600`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("scylladb_table.orders", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("scylladb_table.orders", "cdc.preimage", "false"),
+					resource.TestCheckResourceAttr("scylladb_table.orders", "cdc.postimage", "true"),
+				),
+			},
+			// A zero ttl keeps the log forever
+			{
+				Config: fmt.Sprintf(tableConfig, `enabled   = true
+    preimage  = "false"
+    postimage = true
+    ttl       = 0`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("scylladb_table.orders", "cdc.ttl", "0"),
+				),
+			},
+			// Disabling CDC
+			{
+				Config: fmt.Sprintf(tableConfig, `enabled = false`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("scylladb_table.orders", "cdc.enabled", "false"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccTableResourceScyllaOptions(t *testing.T) {
+	devClusterHost := testutil.NewTestContainer(t)
+	providerConfig := fmt.Sprintf(providerConfigFmt, devClusterHost) + tableKeyspaceConfig
+	tableConfig := providerConfig + `
+resource "scylladb_table" "tenants" {
+  keyspace            = scylladb_keyspace.app.name
+  name                = "tenants"
+  deletion_protection = false
+  columns             = [{ name = "id", type = "int" }, { name = "name", type = "text" }]
+  partition_key       = ["id"]
+  %s
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(tableConfig, `tombstone_gc = { mode = "never" }`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`value must be one of`),
+			},
+			// Create and Read testing
+			{
+				Config: fmt.Sprintf(tableConfig, `tombstone_gc             = { mode = "disabled" }
+  per_partition_rate_limit = { max_writes_per_second = 100 }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("scylladb_table.tenants", "tombstone_gc.mode", "disabled"),
+					resource.TestCheckResourceAttr("scylladb_table.tenants", "per_partition_rate_limit.max_writes_per_second", "100"),
+					resource.TestCheckNoResourceAttr("scylladb_table.tenants", "per_partition_rate_limit.max_reads_per_second"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "scylladb_table.tenants",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"deletion_protection"},
+			},
+			// The options are altered in place, and removing a limit lifts it
+			{
+				Config: fmt.Sprintf(tableConfig, `tombstone_gc = {
+    mode                         = "timeout"
+    propagation_delay_in_seconds = 600
+  }
+  per_partition_rate_limit = { max_reads_per_second = 1000 }`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("scylladb_table.tenants", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("scylladb_table.tenants", "tombstone_gc.mode", "timeout"),
+					resource.TestCheckResourceAttr("scylladb_table.tenants", "tombstone_gc.propagation_delay_in_seconds", "600"),
+					resource.TestCheckResourceAttr("scylladb_table.tenants", "per_partition_rate_limit.max_reads_per_second", "1000"),
+					resource.TestCheckNoResourceAttr("scylladb_table.tenants", "per_partition_rate_limit.max_writes_per_second"),
+				),
+			},
+			// A zero propagation delay is applied rather than left to the cluster
+			{
+				Config: fmt.Sprintf(tableConfig, `tombstone_gc = {
+    mode                         = "timeout"
+    propagation_delay_in_seconds = 0
+  }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("scylladb_table.tenants", "tombstone_gc.propagation_delay_in_seconds", "0"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -0,0 +1,111 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package scylladb
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Preimage modes of CDC. CDCPreimageFull logs every column of the row
+// instead of the changed ones only.
+const (
+	CDCPreimageOff  = "false"
+	CDCPreimageOn   = "true"
+	CDCPreimageFull = "full"
+)
+
+// Delta modes of CDC. CDCDeltaKeys only logs the primary key of the changed
+// rows.
+const (
+	CDCDeltaFull = "full"
+	CDCDeltaKeys = "keys"
+)
+
+// CDCPreimageModes and CDCDeltaModes list the values of the preimage and
+// delta options.
+var (
+	CDCPreimageModes = []string{CDCPreimageOff, CDCPreimageOn, CDCPreimageFull}
+	CDCDeltaModes    = []string{CDCDeltaFull, CDCDeltaKeys}
+)
+
+// cdcLogSuffix is appended to the name of a table to name its CDC log table.
+const cdcLogSuffix = "_scylla_cdc_log"
+
+// defaultCDCTTL is the default time to live in seconds of the rows of a CDC
+// log table.
+const defaultCDCTTL = 86400
+
+// CDC is the change data capture configuration of a table. Changes to a
+// table with CDC enabled are written to its CDC log table.
+type CDC struct {
+	Enabled   bool
+	Preimage  string
+	Postimage bool
+	Delta     string
+	// TTL is the time to live in seconds of the rows of the log table, zero
+	// keeping them forever. A nil TTL is left to the cluster.
+	TTL *int
+}
+
+// CDCLogTable returns the name of the CDC log table of a table.
+func CDCLogTable(table string) string {
+	return table + cdcLogSuffix
+}
+
+// Validate checks the preimage and delta modes and the time to live.
+func (c CDC) Validate() error {
+	if c.Preimage != "" && !slices.Contains(CDCPreimageModes, strings.ToLower(c.Preimage)) {
+		return fmt.Errorf("invalid cdc preimage %q, expected one of %s", c.Preimage, strings.Join(CDCPreimageModes, ", "))
+	}
+	if c.Delta != "" && !slices.Contains(CDCDeltaModes, strings.ToLower(c.Delta)) {
+		return fmt.Errorf("invalid cdc delta %q, expected one of %s", c.Delta, strings.Join(CDCDeltaModes, ", "))
+	}
+	if c.TTL != nil && *c.TTL < 0 {
+		return errors.New("the cdc ttl cannot be negative")
+	}
+	return nil
+}
+
+// options renders the configuration as the map of a `cdc = {...}` option.
+// Empty modes and a nil TTL are left to the cluster, and so is everything but
+// enabled when CDC is disabled.
+func (c CDC) options() map[string]string {
+	options := map[string]string{"enabled": strconv.FormatBool(c.Enabled)}
+	if !c.Enabled {
+		return options
+	}
+	if c.Preimage != "" {
+		options["preimage"] = strings.ToLower(c.Preimage)
+	}
+	options["postimage"] = strconv.FormatBool(c.Postimage)
+	if c.Delta != "" {
+		options["delta"] = strings.ToLower(c.Delta)
+	}
+	if c.TTL != nil {
+		options["ttl"] = strconv.Itoa(*c.TTL)
+	}
+	return options
+}
+
+// parseCDC parses the options of the cdc extension of a table. Missing
+// options take the defaults of the cluster.
+func parseCDC(options map[string]string) CDC {
+	ttl := defaultCDCTTL
+	cdc := CDC{Enabled: options["enabled"] == "true", Preimage: CDCPreimageOff, Delta: CDCDeltaFull, TTL: &ttl}
+	if preimage, ok := options["preimage"]; ok {
+		cdc.Preimage = preimage
+	}
+	cdc.Postimage = options["postimage"] == "true"
+	if delta, ok := options["delta"]; ok {
+		cdc.Delta = delta
+	}
+	if n, err := strconv.Atoi(options["ttl"]); err == nil {
+		ttl = n
+	}
+	return cdc
+}
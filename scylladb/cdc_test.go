@@ -0,0 +1,39 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package scylladb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCDC(t *testing.T) {
+	ttl, defaultTTL := 3600, 86400
+	assert.Equal(t,
+		CDC{Enabled: true, Preimage: CDCPreimageFull, Delta: CDCDeltaKeys, TTL: &ttl},
+		parseCDC(map[string]string{"delta": "keys", "enabled": "true", "postimage": "false", "preimage": "full", "ttl": "3600"}))
+	// Tables that never enabled CDC have no cdc extension
+	assert.Equal(t, CDC{Preimage: CDCPreimageOff, Delta: CDCDeltaFull, TTL: &defaultTTL}, parseCDC(nil))
+}
+
+func TestCDC(t *testing.T) {
+	negative, zero, ttl := -1, 0, 600
+	assert.NoError(t, CDC{Enabled: true, Preimage: "FULL", Delta: CDCDeltaKeys}.Validate())
+	assert.EqualError(t, CDC{Preimage: "before"}.Validate(), `invalid cdc preimage "before", expected one of false, true, full`)
+	assert.EqualError(t, CDC{Delta: "all"}.Validate(), `invalid cdc delta "all", expected one of full, keys`)
+	assert.Error(t, CDC{TTL: &negative}.Validate())
+	assert.NoError(t, CDC{TTL: &zero}.Validate())
+
+	assert.Equal(t, map[string]string{"enabled": "false"}, CDC{Preimage: CDCPreimageOn, TTL: &ttl}.options())
+	assert.Equal(t,
+		map[string]string{"enabled": "true", "preimage": "full", "postimage": "true", "delta": "full", "ttl": "600"},
+		CDC{Enabled: true, Preimage: "Full", Postimage: true, Delta: CDCDeltaFull, TTL: &ttl}.options())
+	// A zero TTL keeps the rows forever and is sent, unlike a nil one
+	assert.Equal(t,
+		map[string]string{"enabled": "true", "postimage": "false", "ttl": "0"},
+		CDC{Enabled: true, TTL: &zero}.options())
+	assert.Equal(t, map[string]string{"enabled": "true", "postimage": "false"}, CDC{Enabled: true}.options())
+	assert.Equal(t, "events_scylla_cdc_log", CDCLogTable("events"))
+}
@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package scylladb
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// QuoteIdentifier quotes name as a case-sensitive CQL identifier, escaping
+// any double quotes it contains.
+func QuoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// QuoteString quotes value as a CQL string literal, escaping any single
+// quotes it contains.
+func QuoteString(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// mapLiteral renders a CQL map literal with string keys and values, sorted
+// by key so that the output is stable.
+func mapLiteral(m map[string]string) string {
+	terms := make(map[string]string, len(m))
+	for k, v := range m {
+		terms[k] = QuoteString(v)
+	}
+	return termMapLiteral(terms)
+}
+
+// termMapLiteral renders a CQL map literal with string keys and values that
+// are already rendered as CQL terms, sorted by key so that the output is
+// stable.
+func termMapLiteral(terms map[string]string) string {
+	keys := make([]string, 0, len(terms))
+	for k := range terms {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	entries := make([]string, 0, len(keys))
+	for _, k := range keys {
+		entries = append(entries, QuoteString(k)+": "+terms[k])
+	}
+	return "{" + strings.Join(entries, ", ") + "}"
+}
+
+// listLiteral renders a CQL list literal of strings.
+func listLiteral(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, v := range values {
+		quoted = append(quoted, QuoteString(v))
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// option is a single `name = value` pair of a WITH clause. The value must
+// already be rendered as a CQL term. An option without a value is written
+// as its name alone, such as `CLUSTERING ORDER BY (...)`.
+type option struct {
+	name  string
+	value string
+}
+
+func boolOption(name string, value bool) option {
+	return option{name: name, value: strconv.FormatBool(value)}
+}
+
+func stringOption(name, value string) option {
+	return option{name: name, value: QuoteString(value)}
+}
+
+func mapOption(name string, value map[string]string) option {
+	return option{name: name, value: mapLiteral(value)}
+}
+
+// statement builds a CQL statement token by token. Keywords are written as
+// is while names and values go through the quoting helpers, so user input
+// can never change the structure of the statement.
+type statement struct {
+	tokens []string
+}
+
+func newStatement(keywords ...string) *statement {
+	return &statement{tokens: keywords}
+}
+
+// keyword appends CQL keywords. It must never be given user input.
+func (s *statement) keyword(keywords ...string) *statement {
+	s.tokens = append(s.tokens, keywords...)
+	return s
+}
+
+// identifier appends a quoted identifier.
+func (s *statement) identifier(name string) *statement {
+	s.tokens = append(s.tokens, QuoteIdentifier(name))
+	return s
+}
+
+// qualifiedIdentifier appends a quoted keyspace-qualified identifier.
+func (s *statement) qualifiedIdentifier(keyspace, name string) *statement {
+	s.tokens = append(s.tokens, QuoteIdentifier(keyspace)+"."+QuoteIdentifier(name))
+	return s
+}
+
+// literal appends a quoted string literal.
+func (s *statement) literal(value string) *statement {
+	s.tokens = append(s.tokens, QuoteString(value))
+	return s
+}
+
+// with appends a WITH clause joining options with AND. Nothing is appended
+// when there are no options.
+func (s *statement) with(options ...option) *statement {
+	if len(options) == 0 {
+		return s
+	}
+	s.tokens = append(s.tokens, "WITH")
+	for i, o := range options {
+		if i > 0 {
+			s.tokens = append(s.tokens, "AND")
+		}
+		if o.value == "" {
+			s.tokens = append(s.tokens, o.name)
+			continue
+		}
+		s.tokens = append(s.tokens, o.name, "=", o.value)
+	}
+	return s
+}
+
+// String returns the statement text.
+func (s *statement) String() string {
+	return strings.Join(s.tokens, " ")
+}
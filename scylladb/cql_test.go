@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package scylladb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuoteIdentifier(t *testing.T) {
+	tests := map[string]string{
+		"users":            `"users"`,
+		"MixedCase":        `"MixedCase"`,
+		"app-reader":       `"app-reader"`,
+		`we"ird`:           `"we""ird"`,
+		`""`:               `""""""`,
+		"ユーザー":             `"ユーザー"`,
+		`x"; DROP TABLE t`: `"x""; DROP TABLE t"`,
+	}
+	for input, expected := range tests {
+		assert.Equal(t, expected, QuoteIdentifier(input), input)
+	}
+}
+
+func TestQuoteString(t *testing.T) {
+	tests := map[string]string{
+		"":           `''`,
+		"app-reader": `'app-reader'`,
+		"it's":       `'it''s'`,
+		"''":         `''''''`,
+		"ユーザー":       `'ユーザー'`,
+		`back\slash`: `'back\slash'`,
+	}
+	for input, expected := range tests {
+		assert.Equal(t, expected, QuoteString(input), input)
+	}
+}
+
+func TestMapLiteral(t *testing.T) {
+	assert.Equal(t, "{}", mapLiteral(nil))
+	assert.Equal(t,
+		`{'a': '1', 'b''s': 'it''s'}`,
+		mapLiteral(map[string]string{"b's": "it's", "a": "1"}),
+	)
+}
+
+func TestStatement(t *testing.T) {
+	stmt := newStatement("SELECT * FROM").
+		qualifiedIdentifier("my ks", `t"1`).
+		keyword("WHERE k =").
+		literal("v'; --").
+		String()
+	assert.Equal(t, `SELECT * FROM "my ks"."t""1" WHERE k = 'v''; --'`, stmt)
+
+	stmt = newStatement("ALTER ROLE").literal("r").with().String()
+	assert.Equal(t, `ALTER ROLE 'r'`, stmt)
+}
+
+func TestRoleStatementsInjection(t *testing.T) {
+	tests := []struct {
+		name     string
+		role     Role
+		expected string
+	}{
+		{
+			name:     "hyphen",
+			role:     Role{Role: "app-reader", CanLogin: true},
+			expected: `CREATE ROLE 'app-reader' WITH LOGIN = true AND SUPERUSER = false`,
+		},
+		{
+			name:     "unicode",
+			role:     Role{Role: "ユーザー"},
+			expected: `CREATE ROLE 'ユーザー' WITH LOGIN = false AND SUPERUSER = false`,
+		},
+		{
+			name:     "quotes",
+			role:     Role{Role: `it's "quoted"`},
+			expected: `CREATE ROLE 'it''s "quoted"' WITH LOGIN = false AND SUPERUSER = false`,
+		},
+		{
+			name:     "name injection",
+			role:     Role{Role: "x' WITH SUPERUSER = true AND LOGIN = true; --"},
+			expected: `CREATE ROLE 'x'' WITH SUPERUSER = true AND LOGIN = true; --' WITH LOGIN = false AND SUPERUSER = false`,
+		},
+		{
+			name:     "password injection",
+			role:     Role{Role: "r", Password: "p' AND SUPERUSER = true AND LOGIN = 'x"},
+			expected: `CREATE ROLE 'r' WITH PASSWORD = 'p'' AND SUPERUSER = true AND LOGIN = ''x' AND LOGIN = false AND SUPERUSER = false`,
+		},
+		{
+			name:     "options injection",
+			role:     Role{Role: "r", Options: map[string]string{"owner": "x'}; DROP ROLE cassandra; --"}},
+			expected: `CREATE ROLE 'r' WITH LOGIN = false AND SUPERUSER = false AND OPTIONS = {'owner': 'x''}; DROP ROLE cassandra; --'}`,
+		},
+		{
+			name:     "hashed password",
+			role:     Role{Role: "r", HashedPassword: "$6$salt$hash"},
+			expected: `CREATE ROLE 'r' WITH HASHED PASSWORD = '$6$salt$hash' AND LOGIN = false AND SUPERUSER = false`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, createRoleStatement(tt.role))
+		})
+	}
+
+	assert.Equal(t,
+		`ALTER ROLE 'a''b' WITH LOGIN = true AND SUPERUSER = true`,
+		alterRoleStatement(Role{Role: "a'b", CanLogin: true, IsSuperuser: true}),
+	)
+	assert.Equal(t,
+		`ALTER ROLE 'r' WITH LOGIN = false AND SUPERUSER = false AND OPTIONS = {}`,
+		alterRoleStatement(Role{Role: "r", Options: map[string]string{}}),
+	)
+}
+
+func TestValidateRoleName(t *testing.T) {
+	assert.Error(t, validateRoleName(""))
+	for _, name := range []string{"app-reader", "it's", "ユーザー", `"quoted"`, "a b"} {
+		assert.NoError(t, validateRoleName(name), name)
+	}
+}
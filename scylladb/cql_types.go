@@ -0,0 +1,402 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package scylladb
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// nativeTypes are the CQL types that are neither parameterized nor
+// user-defined.
+var nativeTypes = []string{
+	"ascii", "bigint", "blob", "boolean", "counter", "date", "decimal", "double", "duration", "float",
+	"inet", "int", "smallint", "text", "time", "timestamp", "timeuuid", "tinyint", "uuid", "varint",
+}
+
+// typeAliases maps the aliases of native types to their names.
+var typeAliases = map[string]string{"varchar": "text"}
+
+// Parameterized CQL types.
+const (
+	FrozenType = "frozen"
+	ListType   = "list"
+	SetType    = "set"
+	MapType    = "map"
+	TupleType  = "tuple"
+	VectorType = "vector"
+)
+
+// CQLType is a parsed CQL type, such as `int`, `frozen<map<text, list<int>>>`
+// or a reference to a user-defined type.
+type CQLType struct {
+	// Name is the lowercase name of a native or parameterized type, or the
+	// name of a user-defined type.
+	Name string
+	// Keyspace optionally qualifies a user-defined type.
+	Keyspace string
+	// UserDefined reports whether the type is a user-defined type.
+	UserDefined bool
+	// Params are the types a parameterized type is made of.
+	Params []CQLType
+	// Dimension is the number of elements of a vector.
+	Dimension int
+}
+
+// ParseType parses a CQL type. Unquoted names are case-insensitive, so
+// `MAP<Text, INT>` is `map<text, int>`.
+func ParseType(s string) (CQLType, error) {
+	p := typeParser{input: s}
+	if err := p.tokenize(); err != nil {
+		return CQLType{}, fmt.Errorf("invalid type %q: %w", s, err)
+	}
+	if len(p.tokens) == 0 {
+		return CQLType{}, errors.New("type cannot be empty")
+	}
+	t, err := p.parseType()
+	if err == nil && p.pos < len(p.tokens) {
+		err = fmt.Errorf("unexpected %s", p.tokens[p.pos].text)
+	}
+	if err == nil {
+		err = t.validate(false)
+	}
+	if err != nil {
+		return CQLType{}, fmt.Errorf("invalid type %q: %w", s, err)
+	}
+	return t, nil
+}
+
+// NormalizeType returns the canonical form of a CQL type.
+func NormalizeType(s string) (string, error) {
+	t, err := ParseType(s)
+	if err != nil {
+		return "", err
+	}
+	return t.String(), nil
+}
+
+// EqualTypes reports whether two CQL types are the same. Types that do not
+// parse are compared ignoring case and spaces.
+func EqualTypes(a, b string) bool {
+	ta, errA := ParseType(a)
+	tb, errB := ParseType(b)
+	if errA != nil || errB != nil {
+		return normalizeType(a) == normalizeType(b)
+	}
+	return ta.Equal(tb)
+}
+
+func normalizeType(columnType string) string {
+	return strings.ToLower(strings.Join(strings.Fields(columnType), ""))
+}
+
+// String returns the canonical form of the type: lowercase names, a space
+// after each comma, and quotes only around the names that need them.
+func (t CQLType) String() string {
+	if t.UserDefined {
+		name := formatIdentifier(t.Name)
+		if isTypeKeyword(t.Name) {
+			// Unquoted, the name would refer to the built-in type
+			name = QuoteIdentifier(t.Name)
+		}
+		if t.Keyspace != "" {
+			name = formatIdentifier(t.Keyspace) + "." + name
+		}
+		return name
+	}
+	if len(t.Params) == 0 {
+		return t.Name
+	}
+	params := make([]string, 0, len(t.Params)+1)
+	for _, p := range t.Params {
+		params = append(params, p.String())
+	}
+	if t.Name == VectorType {
+		params = append(params, strconv.Itoa(t.Dimension))
+	}
+	return t.Name + "<" + strings.Join(params, ", ") + ">"
+}
+
+// Equal reports whether two types are the same type of a column. Frozen
+// markers that change nothing are ignored, since the cluster reports types
+// nested in frozen types as frozen themselves and tuples are always frozen.
+// The keyspace of user-defined types is ignored as well, since a table can
+// only use the types of its own keyspace.
+func (t CQLType) Equal(other CQLType) bool {
+	return t.canonical(false).String() == other.canonical(false).String()
+}
+
+// canonical returns the type with a single frozen marker around each frozen
+// type and without keyspaces. Inside a frozen type everything is frozen.
+func (t CQLType) canonical(frozen bool) CQLType {
+	switch {
+	case t.Name == FrozenType && !t.UserDefined:
+		if frozen {
+			return t.Params[0].canonical(true)
+		}
+		return CQLType{Name: FrozenType, Params: []CQLType{t.Params[0].canonical(true)}}
+	case t.Name == TupleType && !t.UserDefined && !frozen:
+		return CQLType{Name: FrozenType, Params: []CQLType{t.canonical(true)}}
+	case t.UserDefined:
+		return CQLType{Name: t.Name, UserDefined: true}
+	}
+	c := CQLType{Name: t.Name, Dimension: t.Dimension}
+	for _, p := range t.Params {
+		// The elements of collections, tuples and vectors are frozen
+		c.Params = append(c.Params, p.canonical(true))
+	}
+	return c
+}
+
+// isCollection reports whether the type is a non-frozen list, set or map.
+func (t CQLType) isCollection() bool {
+	return !t.UserDefined && (t.Name == ListType || t.Name == SetType || t.Name == MapType)
+}
+
+// validate checks the rules parsing alone does not enforce. Inside a frozen
+// type or a tuple, collections may be nested without being frozen.
+func (t CQLType) validate(frozen bool) error {
+	if t.Name == FrozenType && !t.UserDefined {
+		if p := t.Params[0]; !p.isCollection() && !p.UserDefined && p.Name != TupleType {
+			return fmt.Errorf("frozen is only allowed on collections, tuples and user-defined types, not on %s", p)
+		}
+	}
+	if t.isCollection() && !frozen {
+		for _, p := range t.Params {
+			if p.isCollection() || p.UserDefined {
+				return fmt.Errorf("non-frozen %s is not allowed inside %s, use frozen<%s>", p, t.Name, p)
+			}
+		}
+	}
+	if !t.UserDefined && t.Name != FrozenType && t.Name != TupleType && t.Name != VectorType {
+		for _, p := range t.Params {
+			if p.Name == "counter" && !p.UserDefined {
+				return fmt.Errorf("counter is not allowed inside %s", t.Name)
+			}
+		}
+	}
+	frozen = frozen || t.Name == FrozenType || t.Name == TupleType
+	for _, p := range t.Params {
+		if err := p.validate(frozen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// typeToken is a token of a CQL type: a name, a number or one of `<>,.`.
+type typeToken struct {
+	text   string
+	quoted bool
+}
+
+type typeParser struct {
+	input  string
+	tokens []typeToken
+	pos    int
+}
+
+func (p *typeParser) tokenize() error {
+	s := p.input
+	for len(s) > 0 {
+		switch c := s[0]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			s = s[1:]
+		case strings.IndexByte("<>,.", c) >= 0:
+			p.tokens = append(p.tokens, typeToken{text: string(c)})
+			s = s[1:]
+		case c == '"':
+			// Quoted names are case-sensitive and escape quotes by doubling them
+			var name strings.Builder
+			i := 1
+			for {
+				j := strings.IndexByte(s[i:], '"')
+				if j < 0 {
+					return errors.New("unterminated quoted name")
+				}
+				name.WriteString(s[i : i+j])
+				i += j + 1
+				if i < len(s) && s[i] == '"' {
+					name.WriteByte('"')
+					i++
+					continue
+				}
+				break
+			}
+			if name.Len() == 0 {
+				return errors.New("empty quoted name")
+			}
+			p.tokens = append(p.tokens, typeToken{text: name.String(), quoted: true})
+			s = s[i:]
+		default:
+			word := wordPattern.FindString(s)
+			if word == "" {
+				return fmt.Errorf("unexpected character %q", c)
+			}
+			p.tokens = append(p.tokens, typeToken{text: word})
+			s = s[len(word):]
+		}
+	}
+	return nil
+}
+
+// wordPattern matches unquoted names and numbers.
+var wordPattern = regexp.MustCompile(`^[A-Za-z0-9_]+`)
+
+// namePattern matches the unquoted names of CQL.
+var namePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+func (p *typeParser) next() (typeToken, error) {
+	if p.pos >= len(p.tokens) {
+		return typeToken{}, errors.New("unexpected end of type")
+	}
+	t := p.tokens[p.pos]
+	p.pos++
+	return t, nil
+}
+
+func (p *typeParser) peek(text string) bool {
+	return p.pos < len(p.tokens) && !p.tokens[p.pos].quoted && p.tokens[p.pos].text == text
+}
+
+func (p *typeParser) expect(text string) error {
+	t, err := p.next()
+	if err != nil {
+		return err
+	}
+	if t.quoted || t.text != text {
+		return fmt.Errorf("expected %s, got %s", text, t.text)
+	}
+	return nil
+}
+
+func (p *typeParser) parseName() (string, bool, error) {
+	t, err := p.next()
+	if err != nil {
+		return "", false, err
+	}
+	if t.quoted {
+		return t.text, true, nil
+	}
+	if !namePattern.MatchString(t.text) {
+		return "", false, fmt.Errorf("expected a type, got %s", t.text)
+	}
+	return strings.ToLower(t.text), false, nil
+}
+
+func (p *typeParser) parseType() (CQLType, error) {
+	name, quoted, err := p.parseName()
+	if err != nil {
+		return CQLType{}, err
+	}
+	if !quoted && !p.peek(".") {
+		if alias, ok := typeAliases[name]; ok {
+			name = alias
+		}
+		if slices.Contains(nativeTypes, name) {
+			return CQLType{Name: name}, nil
+		}
+		switch name {
+		case FrozenType, ListType, SetType:
+			return p.parseParams(name, 1, 1)
+		case MapType:
+			return p.parseParams(name, 2, 2)
+		case TupleType:
+			return p.parseParams(name, 1, -1)
+		case VectorType:
+			return p.parseVector()
+		}
+	}
+
+	// Any other name refers to a user-defined type
+	t := CQLType{Name: name, UserDefined: true}
+	if p.peek(".") {
+		p.pos++
+		t.Keyspace = t.Name
+		if t.Name, _, err = p.parseName(); err != nil {
+			return CQLType{}, err
+		}
+	}
+	if p.peek("<") {
+		return CQLType{}, fmt.Errorf("unknown type %s", t)
+	}
+	return t, nil
+}
+
+// parseParams parses the parameters of a parameterized type, at least min
+// and at most max of them, max being -1 for no limit.
+func (p *typeParser) parseParams(name string, minParams, maxParams int) (CQLType, error) {
+	t := CQLType{Name: name}
+	if err := p.expect("<"); err != nil {
+		return CQLType{}, fmt.Errorf("%s requires parameters: %w", name, err)
+	}
+	for {
+		param, err := p.parseType()
+		if err != nil {
+			return CQLType{}, err
+		}
+		t.Params = append(t.Params, param)
+		if !p.peek(",") {
+			break
+		}
+		p.pos++
+	}
+	if err := p.expect(">"); err != nil {
+		return CQLType{}, err
+	}
+	if len(t.Params) < minParams || (maxParams >= 0 && len(t.Params) > maxParams) {
+		expected := strconv.Itoa(minParams)
+		if maxParams < 0 {
+			expected = "at least " + expected
+		}
+		return CQLType{}, fmt.Errorf("%s takes %s parameters, got %d", name, expected, len(t.Params))
+	}
+	return t, nil
+}
+
+// parseVector parses the element type and the dimension of a vector.
+func (p *typeParser) parseVector() (CQLType, error) {
+	if err := p.expect("<"); err != nil {
+		return CQLType{}, fmt.Errorf("vector requires parameters: %w", err)
+	}
+	element, err := p.parseType()
+	if err != nil {
+		return CQLType{}, err
+	}
+	if err := p.expect(","); err != nil {
+		return CQLType{}, fmt.Errorf("vector requires a dimension: %w", err)
+	}
+	t, err := p.next()
+	if err != nil {
+		return CQLType{}, err
+	}
+	dimension, err := strconv.Atoi(t.text)
+	if t.quoted || err != nil || dimension < 1 {
+		return CQLType{}, fmt.Errorf("invalid vector dimension %s", t.text)
+	}
+	if err := p.expect(">"); err != nil {
+		return CQLType{}, err
+	}
+	return CQLType{Name: VectorType, Params: []CQLType{element}, Dimension: dimension}, nil
+}
+
+// isTypeKeyword reports whether an unquoted name refers to a built-in type.
+func isTypeKeyword(name string) bool {
+	_, alias := typeAliases[name]
+	return alias || slices.Contains(nativeTypes, name) ||
+		slices.Contains([]string{FrozenType, ListType, SetType, MapType, TupleType, VectorType}, name)
+}
+
+// formatIdentifier renders a name unquoted when CQL reads it back unchanged,
+// and quoted otherwise.
+func formatIdentifier(name string) string {
+	if namePattern.MatchString(name) && name == strings.ToLower(name) {
+		return name
+	}
+	return QuoteIdentifier(name)
+}
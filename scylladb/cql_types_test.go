@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package scylladb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseType(t *testing.T) {
+	tests := map[string]string{
+		"int":                              "int",
+		" TEXT ":                           "text",
+		"varchar":                          "text",
+		"frozen<map<text,list<int>>>":      "frozen<map<text, list<int>>>",
+		"MAP<Text, frozen<LIST<INT>>>":     "map<text, frozen<list<int>>>",
+		"set<frozen<tuple<int, text>>>":    "set<frozen<tuple<int, text>>>",
+		"tuple<int,  timestamp, inet>":     "tuple<int, timestamp, inet>",
+		"vector<FLOAT, 768>":               "vector<float, 768>",
+		"Address":                          "address",
+		`"Address"`:                        `"Address"`,
+		`app_ks.address`:                   "app_ks.address",
+		`frozen<list<frozen<"Address">>>`:  `frozen<list<frozen<"Address">>>`,
+		`"say ""hi"""`:                     `"say ""hi"""`,
+		`"int"`:                            `"int"`,
+		"map<uuid, frozen<set<duration>>>": "map<uuid, frozen<set<duration>>>",
+	}
+	for input, expected := range tests {
+		normalized, err := NormalizeType(input)
+		assert.NoError(t, err, input)
+		assert.Equal(t, expected, normalized, input)
+	}
+
+	parsed, err := ParseType("vector<float, 3>")
+	assert.NoError(t, err)
+	assert.Equal(t, CQLType{Name: VectorType, Params: []CQLType{{Name: "float"}}, Dimension: 3}, parsed)
+	parsed, err = ParseType(`app_ks."Address"`)
+	assert.NoError(t, err)
+	assert.Equal(t, CQLType{Name: "Address", Keyspace: "app_ks", UserDefined: true}, parsed)
+}
+
+func TestParseTypeErrors(t *testing.T) {
+	tests := map[string]string{
+		"":                           "type cannot be empty",
+		"int); DROP TABLE x; --":     `invalid type "int); DROP TABLE x; --": unexpected character ')'`,
+		"list<int":                   `invalid type "list<int": unexpected end of type`,
+		"list<int>>":                 `invalid type "list<int>>": unexpected >`,
+		"list":                       `invalid type "list": list requires parameters: unexpected end of type`,
+		"map<int>":                   `invalid type "map<int>": map takes 2 parameters, got 1`,
+		"list<list<int>>":            `invalid type "list<list<int>>": non-frozen list<int> is not allowed inside list, use frozen<list<int>>`,
+		"set<address>":               `invalid type "set<address>": non-frozen address is not allowed inside set, use frozen<address>`,
+		"frozen<int>":                `invalid type "frozen<int>": frozen is only allowed on collections, tuples and user-defined types, not on int`,
+		"list<counter>":              `invalid type "list<counter>": counter is not allowed inside list`,
+		"vector<float>":              `invalid type "vector<float>": vector requires a dimension: expected ,, got >`,
+		"vector<float, 0>":           `invalid type "vector<float, 0>": invalid vector dimension 0`,
+		"address<int>":               `invalid type "address<int>": unknown type address`,
+		`"unterminated`:              `invalid type "\"unterminated": unterminated quoted name`,
+		"1nt":                        `invalid type "1nt": expected a type, got 1nt`,
+		"tuple<>":                    `invalid type "tuple<>": expected a type, got >`,
+		"frozen<map<text, int>> int": `invalid type "frozen<map<text, int>> int": unexpected int`,
+	}
+	for input, expected := range tests {
+		_, err := ParseType(input)
+		assert.EqualError(t, err, expected, input)
+	}
+}
+
+func TestEqualTypes(t *testing.T) {
+	equal := [][2]string{
+		{"frozen<map<text, list<int>>>", "frozen<map<text, frozen<list<int>>>>"},
+		{"tuple<int, text>", "frozen<tuple<int, text>>"},
+		{"tuple<int, list<int>>", "frozen<tuple<int, frozen<list<int>>>>"},
+		{"VARCHAR", "text"},
+		{"app_ks.address", "address"},
+		{"frozen<Address>", `frozen<"address">`},
+		{"vector<FLOAT,768>", "vector<float, 768>"},
+	}
+	for _, types := range equal {
+		assert.True(t, EqualTypes(types[0], types[1]), types)
+	}
+	different := [][2]string{
+		{"list<int>", "frozen<list<int>>"},
+		{"address", "frozen<address>"},
+		{`"Address"`, "address"},
+		{"vector<float, 768>", "vector<float, 384>"},
+		{"map<text, int>", "map<int, text>"},
+	}
+	for _, types := range different {
+		assert.False(t, EqualTypes(types[0], types[1]), types)
+	}
+}
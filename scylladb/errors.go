@@ -0,0 +1,35 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package scylladb
+
+import (
+	"errors"
+	"fmt"
+
+	gocql "github.com/apache/cassandra-gocql-driver/v2"
+)
+
+// ErrRoleNotFound is returned when a role does not exist in the cluster.
+var ErrRoleNotFound = errors.New("role not found")
+
+// ErrKeyspaceNotFound is returned when a keyspace does not exist in the
+// cluster.
+var ErrKeyspaceNotFound = errors.New("keyspace not found")
+
+// ErrTableNotFound is returned when a table does not exist in the cluster.
+var ErrTableNotFound = errors.New("table not found")
+
+// ErrLockout is returned when a change would leave the cluster without a way
+// for the provider, or anyone, to administer it.
+var ErrLockout = errors.New("change would lock out the cluster administrators")
+
+// notFound translates the driver's not found error into sentinel, keeping
+// the name of the missing object in the message. Other errors are returned
+// unchanged.
+func notFound(err error, sentinel error, name string) error {
+	if errors.Is(err, gocql.ErrNotFound) {
+		return fmt.Errorf("%w: %s", sentinel, name)
+	}
+	return err
+}
@@ -0,0 +1,22 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package scylladb
+
+import (
+	"errors"
+	"testing"
+
+	gocql "github.com/apache/cassandra-gocql-driver/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotFound(t *testing.T) {
+	err := notFound(gocql.ErrNotFound, ErrRoleNotFound, "reader")
+	assert.ErrorIs(t, err, ErrRoleNotFound)
+	assert.EqualError(t, err, "role not found: reader")
+
+	other := errors.New("connection refused")
+	assert.Equal(t, other, notFound(other, ErrRoleNotFound, "reader"))
+	assert.NoError(t, notFound(nil, ErrRoleNotFound, "reader"))
+}
@@ -0,0 +1,455 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package scylladb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math/bits"
+	"slices"
+	"strconv"
+	"strings"
+
+	gocql "github.com/apache/cassandra-gocql-driver/v2"
+)
+
+// Replication classes supported when creating or altering a keyspace.
+const (
+	SimpleStrategy          = "SimpleStrategy"
+	NetworkTopologyStrategy = "NetworkTopologyStrategy"
+)
+
+// ReplicationClasses lists the replication classes keyspaces can be created
+// with.
+var ReplicationClasses = []string{SimpleStrategy, NetworkTopologyStrategy}
+
+// replicationClassPrefix is the package of the replication classes, which
+// the cluster reports in front of their short names.
+const replicationClassPrefix = "org.apache.cassandra.locator."
+
+type Keyspace struct {
+	Name          string
+	Replication   Replication
+	DurableWrites bool
+	// Tablets is only used when creating a keyspace, since tablets cannot be
+	// turned on or off afterwards. Nil leaves the choice to the cluster.
+	// GetKeyspace always populates it.
+	Tablets *Tablets
+}
+
+// Tablets configures the tablets based replication of a keyspace. An
+// Initial of zero lets the cluster pick the initial number of tablets.
+type Tablets struct {
+	Enabled bool
+	Initial int
+}
+
+// NormalizeTablets returns the tablets configuration the way the cluster
+// reports it once the keyspace exists: the initial number of tablets is
+// rounded up to a power of two, and a keyspace without tablets has none.
+func NormalizeTablets(t Tablets) Tablets {
+	if !t.Enabled {
+		return Tablets{}
+	}
+	if t.Initial > 0 {
+		t.Initial = 1 << bits.Len(uint(t.Initial-1))
+	}
+	return t
+}
+
+// options renders the tablets as the map of a `tablets = {...}` option.
+func (t Tablets) options() map[string]string {
+	options := map[string]string{"enabled": strconv.FormatBool(t.Enabled)}
+	if t.Enabled && t.Initial > 0 {
+		options["initial"] = strconv.Itoa(t.Initial)
+	}
+	return options
+}
+
+// Replication is the replication strategy of a keyspace. ReplicationFactor
+// is only used by SimpleStrategy and DataCenters, the replication factor of
+// each data center, only by NetworkTopologyStrategy.
+type Replication struct {
+	// Class is the short name of the replication class.
+	Class             string
+	ReplicationFactor int
+	DataCenters       map[string]int
+	// Racks pins the replicas of a NetworkTopologyStrategy data center to a
+	// list of its racks, one replica per rack, instead of giving a
+	// replication factor in DataCenters.
+	Racks map[string][]string
+}
+
+// NormalizeReplicationClass returns the short name of a replication class,
+// so that `NetworkTopologyStrategy` and
+// `org.apache.cassandra.locator.NetworkTopologyStrategy` compare equal.
+func NormalizeReplicationClass(class string) string {
+	return strings.TrimPrefix(strings.TrimSpace(class), replicationClassPrefix)
+}
+
+// ParseReplication parses the replication map of a keyspace as stored in
+// system_schema.keyspaces, where the class may be a fully qualified name and
+// the replication factors are strings.
+func ParseReplication(options map[string]string) (Replication, error) {
+	r := Replication{Class: NormalizeReplicationClass(options["class"])}
+	switch r.Class {
+	case SimpleStrategy:
+		rf, err := parseReplicationFactor("replication_factor", options["replication_factor"])
+		if err != nil {
+			return Replication{}, err
+		}
+		r.ReplicationFactor = rf
+	case NetworkTopologyStrategy:
+		r.DataCenters = map[string]int{}
+		for dc, value := range options {
+			if dc == "class" {
+				continue
+			}
+			if strings.HasPrefix(strings.TrimSpace(value), "[") {
+				if r.Racks == nil {
+					r.Racks = map[string][]string{}
+				}
+				r.Racks[dc] = parseRackList(value)
+				continue
+			}
+			rf, err := parseReplicationFactor(dc, value)
+			if err != nil {
+				return Replication{}, err
+			}
+			r.DataCenters[dc] = rf
+		}
+	}
+	return r, nil
+}
+
+// replicationOptionsV2 flattens the replication_v2 column of
+// system_schema.keyspaces, which stores every option as a list, into the
+// options ParseReplication expects. Options holding a single value, the
+// class and the replication factors, are kept as is and rack lists are
+// rendered as list literals.
+func replicationOptionsV2(options map[string][]string) map[string]string {
+	flat := make(map[string]string, len(options))
+	for key, values := range options {
+		if len(values) == 1 {
+			if _, err := strconv.Atoi(values[0]); err == nil || key == "class" {
+				flat[key] = values[0]
+				continue
+			}
+		}
+		flat[key] = listLiteral(values)
+	}
+	return flat
+}
+
+// parseRackList parses a rack list as rendered by the cluster, such as
+// `['rack1', 'rack2']`, into sorted rack names.
+func parseRackList(value string) []string {
+	value = strings.TrimSpace(value)
+	value = strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")
+	racks := []string{}
+	for _, rack := range strings.Split(value, ",") {
+		rack = strings.Trim(strings.TrimSpace(rack), `'"`)
+		if rack != "" {
+			racks = append(racks, rack)
+		}
+	}
+	slices.Sort(racks)
+	return racks
+}
+
+func parseReplicationFactor(name, value string) (int, error) {
+	rf, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("invalid replication factor %q for %s", value, name)
+	}
+	return rf, nil
+}
+
+// Validate checks that the replication can be used to create or alter a
+// keyspace.
+func (r Replication) Validate() error {
+	switch r.Class {
+	case SimpleStrategy:
+		if r.ReplicationFactor < 1 {
+			return errors.New("SimpleStrategy requires a replication factor of at least 1")
+		}
+		if len(r.DataCenters) > 0 || len(r.Racks) > 0 {
+			return errors.New("SimpleStrategy does not accept per data center replication factors")
+		}
+	case NetworkTopologyStrategy:
+		if r.ReplicationFactor != 0 {
+			return errors.New("NetworkTopologyStrategy takes a replication factor per data center")
+		}
+		if len(r.DataCenters) == 0 && len(r.Racks) == 0 {
+			return errors.New("NetworkTopologyStrategy requires at least one data center")
+		}
+		for dc, rf := range r.DataCenters {
+			if err := validateDataCenterName(dc); err != nil {
+				return err
+			}
+			if rf < 1 {
+				return fmt.Errorf("the replication factor of %s must be at least 1", dc)
+			}
+		}
+		for dc, racks := range r.Racks {
+			if err := validateDataCenterName(dc); err != nil {
+				return err
+			}
+			if _, ok := r.DataCenters[dc]; ok {
+				return fmt.Errorf("%s has both a replication factor and a list of racks", dc)
+			}
+			if len(racks) == 0 {
+				return fmt.Errorf("the list of racks of %s cannot be empty", dc)
+			}
+			seen := map[string]bool{}
+			for _, rack := range racks {
+				if rack == "" {
+					return fmt.Errorf("the list of racks of %s contains an empty rack name", dc)
+				}
+				if seen[rack] {
+					return fmt.Errorf("the list of racks of %s contains %s more than once", dc, rack)
+				}
+				seen[rack] = true
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported replication class %q, expected one of %s", r.Class, strings.Join(ReplicationClasses, ", "))
+	}
+	return nil
+}
+
+func validateDataCenterName(dc string) error {
+	if dc == "" || dc == "class" || dc == "replication_factor" {
+		return fmt.Errorf("invalid data center name %q", dc)
+	}
+	return nil
+}
+
+// Equal reports whether two replications place the same replicas. The order
+// of the racks of a data center does not matter.
+func (r Replication) Equal(other Replication) bool {
+	return NormalizeReplicationClass(r.Class) == NormalizeReplicationClass(other.Class) &&
+		r.ReplicationFactor == other.ReplicationFactor &&
+		maps.Equal(r.DataCenters, other.DataCenters) &&
+		maps.EqualFunc(r.Racks, other.Racks, sameRacks)
+}
+
+// sameRacks reports whether two lists of racks hold the same racks.
+func sameRacks(a, b []string) bool {
+	return slices.Equal(sortedRacks(a), sortedRacks(b))
+}
+
+func sortedRacks(racks []string) []string {
+	sorted := slices.Clone(racks)
+	slices.Sort(sorted)
+	return sorted
+}
+
+// literal renders the replication as the map literal of a
+// `replication = {...}` option. Rack lists are rendered as lists.
+func (r Replication) literal() string {
+	terms := map[string]string{"class": QuoteString(NormalizeReplicationClass(r.Class))}
+	if r.ReplicationFactor > 0 {
+		terms["replication_factor"] = QuoteString(strconv.Itoa(r.ReplicationFactor))
+	}
+	for dc, rf := range r.DataCenters {
+		terms[dc] = QuoteString(strconv.Itoa(rf))
+	}
+	for dc, racks := range r.Racks {
+		terms[dc] = listLiteral(sortedRacks(racks))
+	}
+	return termMapLiteral(terms)
+}
+
+// GetKeyspace reads a keyspace. Rack lists are read from the replication_v2
+// column when the cluster has it, since the replication column only holds
+// their replication factor.
+func (c *Cluster) GetKeyspace(name string) (Keyspace, error) {
+	keyspace := Keyspace{Name: name}
+	query := newStatement("SELECT * FROM").
+		qualifiedIdentifier("system_schema", "keyspaces").
+		keyword("WHERE keyspace_name = ?").
+		String()
+	row := map[string]any{}
+	iter := c.Session.Query(query, name).Iter()
+	found := iter.MapScan(row)
+	if err := iter.Close(); err != nil {
+		return Keyspace{}, err
+	}
+	if !found {
+		return Keyspace{}, notFound(gocql.ErrNotFound, ErrKeyspaceNotFound, name)
+	}
+	keyspace.DurableWrites, _ = row["durable_writes"].(bool)
+	replication, _ := row["replication"].(map[string]string)
+	if v2, ok := row["replication_v2"].(map[string][]string); ok && len(v2) > 0 {
+		replication = replicationOptionsV2(v2)
+	}
+	r, err := ParseReplication(replication)
+	if err != nil {
+		return Keyspace{}, fmt.Errorf("failed to parse the replication of %s: %w", name, err)
+	}
+	keyspace.Replication = r
+
+	tablets, err := c.getKeyspaceTablets(name)
+	if err != nil {
+		return Keyspace{}, err
+	}
+	keyspace.Tablets = &tablets
+	return keyspace, nil
+}
+
+// getKeyspaceTablets reads the effective tablets configuration of a keyspace
+// from system_schema.scylla_keyspaces, which has no initial tablets for
+// keyspaces that use vnodes.
+func (c *Cluster) getKeyspaceTablets(name string) (Tablets, error) {
+	var initial *int
+	query := newStatement("SELECT initial_tablets FROM").
+		qualifiedIdentifier("system_schema", "scylla_keyspaces").
+		keyword("WHERE keyspace_name = ?").
+		String()
+	err := c.Session.Query(query, name).Scan(&initial)
+	if errors.Is(err, gocql.ErrNotFound) {
+		return Tablets{}, nil
+	}
+	if err != nil {
+		return Tablets{}, err
+	}
+	if initial == nil {
+		return Tablets{}, nil
+	}
+	return Tablets{Enabled: true, Initial: *initial}, nil
+}
+
+// ListKeyspaces returns the names of the keyspaces of the cluster, sorted by
+// name.
+func (c *Cluster) ListKeyspaces() ([]string, error) {
+	query := newStatement("SELECT keyspace_name FROM").qualifiedIdentifier("system_schema", "keyspaces").String()
+	iter := c.Session.Query(query).Iter()
+	keyspaces := []string{}
+	var keyspace string
+	for iter.Scan(&keyspace) {
+		keyspaces = append(keyspaces, keyspace)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	slices.Sort(keyspaces)
+	return keyspaces, nil
+}
+
+// IsSystemKeyspace reports whether a keyspace belongs to the cluster itself,
+// such as system, system_schema or system_auth.
+func IsSystemKeyspace(name string) bool {
+	return name == "system" || strings.HasPrefix(name, "system_")
+}
+
+// ListViews returns the names of the materialized views of a keyspace,
+// sorted by name.
+func (c *Cluster) ListViews(keyspace string) ([]string, error) {
+	return c.listSchemaNames("views", "view_name", keyspace)
+}
+
+// ListTypes returns the names of the user defined types of a keyspace,
+// sorted by name.
+func (c *Cluster) ListTypes(keyspace string) ([]string, error) {
+	return c.listSchemaNames("types", "type_name", keyspace)
+}
+
+// listSchemaNames returns the names in column of the rows of the
+// system_schema table that belong to keyspace, sorted.
+func (c *Cluster) listSchemaNames(table, column, keyspace string) ([]string, error) {
+	query := newStatement("SELECT").identifier(column).keyword("FROM").
+		qualifiedIdentifier("system_schema", table).
+		keyword("WHERE keyspace_name = ?").
+		String()
+	iter := c.Session.Query(query, keyspace).Iter()
+	names := []string{}
+	var name string
+	for iter.Scan(&name) {
+		names = append(names, name)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+func (c *Cluster) CreateKeyspace(keyspace Keyspace) error {
+	if err := validateKeyspace(keyspace); err != nil {
+		return err
+	}
+	return c.Session.Query(createKeyspaceStatement(keyspace)).Exec()
+}
+
+// AlterKeyspace changes the replication and the durable writes of a
+// keyspace. The replication of a tablets keyspace is changed one step at a
+// time, see ReplicationSteps, and waiting for the tablets to move stops when
+// ctx is done.
+func (c *Cluster) AlterKeyspace(ctx context.Context, keyspace Keyspace) error {
+	if err := validateKeyspace(keyspace); err != nil {
+		return err
+	}
+	current, err := c.GetKeyspace(keyspace.Name)
+	if err != nil {
+		return err
+	}
+	if current.Tablets.Enabled && !current.Replication.Equal(keyspace.Replication) {
+		return c.alterReplicationStepwise(ctx, current, keyspace)
+	}
+	if err := c.Session.Query(alterKeyspaceStatement(keyspace)).Exec(); err != nil {
+		return err
+	}
+	return c.Session.AwaitSchemaAgreement(ctx)
+}
+
+func (c *Cluster) DropKeyspace(name string) error {
+	if err := validateKeyspaceName(name); err != nil {
+		return err
+	}
+	return c.Session.Query(newStatement("DROP KEYSPACE").identifier(name).String()).Exec()
+}
+
+func createKeyspaceStatement(keyspace Keyspace) string {
+	options := keyspaceOptions(keyspace)
+	if keyspace.Tablets != nil {
+		options = append(options, mapOption("tablets", keyspace.Tablets.options()))
+	}
+	return newStatement("CREATE KEYSPACE").identifier(keyspace.Name).with(options...).String()
+}
+
+func alterKeyspaceStatement(keyspace Keyspace) string {
+	return newStatement("ALTER KEYSPACE").identifier(keyspace.Name).with(keyspaceOptions(keyspace)...).String()
+}
+
+func keyspaceOptions(keyspace Keyspace) []option {
+	return []option{
+		{name: "replication", value: keyspace.Replication.literal()},
+		boolOption("durable_writes", keyspace.DurableWrites),
+	}
+}
+
+func validateKeyspace(keyspace Keyspace) error {
+	if err := validateKeyspaceName(keyspace.Name); err != nil {
+		return err
+	}
+	if keyspace.Tablets != nil && keyspace.Tablets.Initial < 0 {
+		return errors.New("the initial number of tablets cannot be negative")
+	}
+	return keyspace.Replication.Validate()
+}
+
+// validateKeyspaceName rejects names the cluster would never accept. Names
+// are always quoted, so any other character is safe.
+func validateKeyspaceName(name string) error {
+	if name == "" {
+		return errors.New("keyspace name cannot be empty")
+	}
+	if len(name) > 48 {
+		return fmt.Errorf("keyspace name %q is longer than 48 characters", name)
+	}
+	return nil
+}
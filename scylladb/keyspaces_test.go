@@ -0,0 +1,224 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package scylladb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseReplication(t *testing.T) {
+	r, err := ParseReplication(map[string]string{
+		"class": "org.apache.cassandra.locator.NetworkTopologyStrategy",
+		"dc1":   "3",
+		"dc2":   " 1",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, Replication{Class: NetworkTopologyStrategy, DataCenters: map[string]int{"dc1": 3, "dc2": 1}}, r)
+
+	r, err = ParseReplication(map[string]string{"class": "SimpleStrategy", "replication_factor": "2"})
+	assert.NoError(t, err)
+	assert.Equal(t, Replication{Class: SimpleStrategy, ReplicationFactor: 2}, r)
+
+	r, err = ParseReplication(map[string]string{"class": "org.apache.cassandra.locator.LocalStrategy"})
+	assert.NoError(t, err)
+	assert.Equal(t, Replication{Class: "LocalStrategy"}, r)
+
+	r, err = ParseReplication(map[string]string{"class": "NetworkTopologyStrategy", "dc1": "['rack2', 'rack1']", "dc2": "1"})
+	assert.NoError(t, err)
+	assert.Equal(t, Replication{
+		Class:       NetworkTopologyStrategy,
+		DataCenters: map[string]int{"dc2": 1},
+		Racks:       map[string][]string{"dc1": {"rack1", "rack2"}},
+	}, r)
+
+	r, err = ParseReplication(replicationOptionsV2(map[string][]string{
+		"class": {"org.apache.cassandra.locator.NetworkTopologyStrategy"},
+		"dc1":   {"rack1"},
+		"dc2":   {"3"},
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, Replication{
+		Class:       NetworkTopologyStrategy,
+		DataCenters: map[string]int{"dc2": 3},
+		Racks:       map[string][]string{"dc1": {"rack1"}},
+	}, r)
+
+	_, err = ParseReplication(map[string]string{"class": "NetworkTopologyStrategy", "dc1": "three"})
+	assert.Error(t, err)
+}
+
+func TestReplicationValidate(t *testing.T) {
+	valid := []Replication{
+		{Class: SimpleStrategy, ReplicationFactor: 1},
+		{Class: NetworkTopologyStrategy, DataCenters: map[string]int{"dc1": 3}},
+		{Class: NetworkTopologyStrategy, Racks: map[string][]string{"dc1": {"rack1", "rack2"}}},
+		{Class: NetworkTopologyStrategy, DataCenters: map[string]int{"dc1": 3}, Racks: map[string][]string{"dc2": {"rack1"}}},
+	}
+	for _, r := range valid {
+		assert.NoError(t, r.Validate(), r)
+	}
+
+	invalid := []Replication{
+		{Class: SimpleStrategy},
+		{Class: SimpleStrategy, ReplicationFactor: 1, DataCenters: map[string]int{"dc1": 3}},
+		{Class: NetworkTopologyStrategy},
+		{Class: NetworkTopologyStrategy, ReplicationFactor: 3, DataCenters: map[string]int{"dc1": 3}},
+		{Class: NetworkTopologyStrategy, DataCenters: map[string]int{"dc1": 0}},
+		{Class: NetworkTopologyStrategy, DataCenters: map[string]int{"class": 1}},
+		{Class: NetworkTopologyStrategy, Racks: map[string][]string{"dc1": {}}},
+		{Class: NetworkTopologyStrategy, Racks: map[string][]string{"dc1": {"rack1", "rack1"}}},
+		{Class: NetworkTopologyStrategy, Racks: map[string][]string{"dc1": {""}}},
+		{Class: NetworkTopologyStrategy, DataCenters: map[string]int{"dc1": 1}, Racks: map[string][]string{"dc1": {"rack1"}}},
+		{Class: SimpleStrategy, ReplicationFactor: 1, Racks: map[string][]string{"dc1": {"rack1"}}},
+		{Class: "LocalStrategy"},
+	}
+	for _, r := range invalid {
+		assert.Error(t, r.Validate(), r)
+	}
+}
+
+func TestReplicationEqual(t *testing.T) {
+	short := Replication{Class: "NetworkTopologyStrategy", DataCenters: map[string]int{"dc1": 3}}
+	long := Replication{Class: "org.apache.cassandra.locator.NetworkTopologyStrategy", DataCenters: map[string]int{"dc1": 3}}
+	assert.True(t, short.Equal(long))
+	assert.False(t, short.Equal(Replication{Class: NetworkTopologyStrategy, DataCenters: map[string]int{"dc1": 2}}))
+	assert.False(t, short.Equal(Replication{Class: SimpleStrategy, ReplicationFactor: 3}))
+
+	racks := Replication{Class: NetworkTopologyStrategy, Racks: map[string][]string{"dc1": {"rack1", "rack2"}}}
+	assert.True(t, racks.Equal(Replication{Class: NetworkTopologyStrategy, Racks: map[string][]string{"dc1": {"rack2", "rack1"}}}))
+	assert.False(t, racks.Equal(Replication{Class: NetworkTopologyStrategy, Racks: map[string][]string{"dc1": {"rack1", "rack3"}}}))
+	assert.False(t, racks.Equal(Replication{Class: NetworkTopologyStrategy, DataCenters: map[string]int{"dc1": 2}}))
+}
+
+func TestKeyspaceStatements(t *testing.T) {
+	keyspace := Keyspace{
+		Name:          "my_ks",
+		Replication:   Replication{Class: NetworkTopologyStrategy, DataCenters: map[string]int{"dc2": 1, "dc1": 3}},
+		DurableWrites: true,
+	}
+	assert.Equal(t,
+		`CREATE KEYSPACE "my_ks" WITH replication = {'class': 'NetworkTopologyStrategy', 'dc1': '3', 'dc2': '1'} AND durable_writes = true`,
+		createKeyspaceStatement(keyspace))
+
+	keyspace.Tablets = &Tablets{Enabled: true, Initial: 8}
+	assert.Equal(t,
+		`CREATE KEYSPACE "my_ks" WITH replication = {'class': 'NetworkTopologyStrategy', 'dc1': '3', 'dc2': '1'} AND durable_writes = true AND tablets = {'enabled': 'true', 'initial': '8'}`,
+		createKeyspaceStatement(keyspace))
+
+	keyspace.Tablets = &Tablets{Initial: 8}
+	assert.Equal(t,
+		`CREATE KEYSPACE "my_ks" WITH replication = {'class': 'NetworkTopologyStrategy', 'dc1': '3', 'dc2': '1'} AND durable_writes = true AND tablets = {'enabled': 'false'}`,
+		createKeyspaceStatement(keyspace))
+
+	keyspace.Tablets = nil
+	keyspace.Replication.Racks = map[string][]string{"dc3": {"rack2", "rack1"}}
+	assert.Equal(t,
+		`CREATE KEYSPACE "my_ks" WITH replication = {'class': 'NetworkTopologyStrategy', 'dc1': '3', 'dc2': '1', 'dc3': ['rack1', 'rack2']} AND durable_writes = true`,
+		createKeyspaceStatement(keyspace))
+
+	// Tablets cannot be changed once the keyspace exists
+	keyspace.Replication = Replication{Class: SimpleStrategy, ReplicationFactor: 2}
+	keyspace.DurableWrites = false
+	assert.Equal(t,
+		`ALTER KEYSPACE "my_ks" WITH replication = {'class': 'SimpleStrategy', 'replication_factor': '2'} AND durable_writes = false`,
+		alterKeyspaceStatement(keyspace))
+}
+
+func TestNormalizeTablets(t *testing.T) {
+	tests := map[Tablets]Tablets{
+		{Enabled: true}:              {Enabled: true},
+		{Enabled: true, Initial: 1}:  {Enabled: true, Initial: 1},
+		{Enabled: true, Initial: 3}:  {Enabled: true, Initial: 4},
+		{Enabled: true, Initial: 8}:  {Enabled: true, Initial: 8},
+		{Enabled: true, Initial: 9}:  {Enabled: true, Initial: 16},
+		{Enabled: false, Initial: 8}: {},
+	}
+	for tablets, expected := range tests {
+		assert.Equal(t, expected, NormalizeTablets(tablets), tablets)
+	}
+}
+
+func TestKeyspace(t *testing.T) {
+	cluster := newTestCluster(t)
+	defer cluster.Session.Close()
+
+	keyspace := Keyspace{
+		Name:          "app_ks",
+		Replication:   Replication{Class: NetworkTopologyStrategy, DataCenters: map[string]int{"datacenter1": 1}},
+		DurableWrites: true,
+		Tablets:       &Tablets{Enabled: true, Initial: 4},
+	}
+	assert.NoError(t, cluster.CreateKeyspace(keyspace))
+
+	got, err := cluster.GetKeyspace("app_ks")
+	assert.NoError(t, err)
+	assert.Equal(t, keyspace, got)
+
+	keyspace.DurableWrites = false
+	assert.NoError(t, cluster.AlterKeyspace(t.Context(), keyspace))
+	got, err = cluster.GetKeyspace("app_ks")
+	assert.NoError(t, err)
+	assert.Equal(t, keyspace, got)
+
+	assert.NoError(t, cluster.DropKeyspace("app_ks"))
+	_, err = cluster.GetKeyspace("app_ks")
+	assert.ErrorIs(t, err, ErrKeyspaceNotFound)
+	assert.EqualError(t, err, "keyspace not found: app_ks")
+
+	vnodes := Keyspace{
+		Name:          "vnodes_ks",
+		Replication:   Replication{Class: NetworkTopologyStrategy, DataCenters: map[string]int{"datacenter1": 1}},
+		DurableWrites: true,
+		Tablets:       &Tablets{Enabled: false},
+	}
+	assert.NoError(t, cluster.CreateKeyspace(vnodes))
+	got, err = cluster.GetKeyspace("vnodes_ks")
+	assert.NoError(t, err)
+	assert.Equal(t, vnodes, got)
+}
+
+func TestIsSystemKeyspace(t *testing.T) {
+	for _, name := range []string{"system", "system_schema", "system_auth", "system_distributed"} {
+		assert.True(t, IsSystemKeyspace(name), name)
+	}
+	for _, name := range []string{"app_ks", "systems", "my_system"} {
+		assert.False(t, IsSystemKeyspace(name), name)
+	}
+}
+
+func TestListKeyspaceContents(t *testing.T) {
+	cluster := newTestCluster(t)
+	defer cluster.Session.Close()
+
+	assert.NoError(t, cluster.CreateKeyspace(Keyspace{
+		Name:          "catalog_ks",
+		Replication:   Replication{Class: NetworkTopologyStrategy, DataCenters: map[string]int{"datacenter1": 1}},
+		DurableWrites: true,
+		Tablets:       &Tablets{Enabled: false},
+	}))
+	for _, query := range []string{
+		`CREATE TYPE "catalog_ks"."price" (amount decimal, currency text)`,
+		`CREATE TABLE "catalog_ks"."items" (id int PRIMARY KEY, category text)`,
+		`CREATE MATERIALIZED VIEW "catalog_ks"."items_by_category" AS SELECT * FROM "catalog_ks"."items" WHERE category IS NOT NULL AND id IS NOT NULL PRIMARY KEY (category, id)`,
+	} {
+		assert.NoError(t, cluster.Session.Query(query).Exec())
+	}
+
+	keyspaces, err := cluster.ListKeyspaces()
+	assert.NoError(t, err)
+	assert.Contains(t, keyspaces, "catalog_ks")
+	assert.Contains(t, keyspaces, "system")
+
+	tables, err := cluster.ListTables("catalog_ks")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"items"}, tables)
+	views, err := cluster.ListViews("catalog_ks")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"items_by_category"}, views)
+	types, err := cluster.ListTypes("catalog_ks")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"price"}, types)
+}
@@ -0,0 +1,178 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package scylladb
+
+import (
+	"errors"
+	"fmt"
+
+	gocql "github.com/apache/cassandra-gocql-driver/v2"
+)
+
+// DefaultSuperuser is the superuser every new cluster ships with.
+const DefaultSuperuser = "cassandra"
+
+// Lockdown describes the hardening of a default superuser: Superuser
+// replaces DefaultRole, which then loses its login or, when Drop is set, is
+// dropped.
+type Lockdown struct {
+	DefaultRole string
+	Superuser   string
+	// Password is the password of Superuser. It is write-only and never
+	// read back from the cluster.
+	Password string
+	Drop     bool
+}
+
+func (l Lockdown) validate() error {
+	if err := validateRoleName(l.DefaultRole); err != nil {
+		return err
+	}
+	if err := validateRoleName(l.Superuser); err != nil {
+		return err
+	}
+	if l.Superuser == l.DefaultRole {
+		return fmt.Errorf("the superuser must differ from the default role %s", l.DefaultRole)
+	}
+	if l.Password == "" {
+		return errors.New("the password of the superuser is required")
+	}
+	return nil
+}
+
+// LockDown creates or updates the replacement superuser and logs in as it
+// with a new session, which then disables the login of the default role or
+// drops it. The change goes through CheckLockout, and running it as the
+// replacement superuser lets the session of the cluster stay logged in as
+// the default role, whose primary role cannot be dropped by its own login.
+// It can be run again on a cluster that is already locked down.
+func (c *Cluster) LockDown(l Lockdown) error {
+	if err := l.validate(); err != nil {
+		return err
+	}
+	if err := c.ensureSuperuser(l.Superuser, l.Password); err != nil {
+		return fmt.Errorf("failed to set up the superuser %s: %w", l.Superuser, err)
+	}
+	superuser, err := c.loginAs(l.Superuser, l.Password)
+	if err != nil {
+		return fmt.Errorf("the superuser %s cannot log in, leaving %s untouched: %w", l.Superuser, l.DefaultRole, err)
+	}
+	defer superuser.Session.Close()
+
+	defaultRole, err := superuser.GetRole(l.DefaultRole)
+	if errors.Is(err, ErrRoleNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !l.Drop && !defaultRole.CanLogin {
+		return nil
+	}
+	var desired *Role
+	if !l.Drop {
+		desired = &Role{Role: defaultRole.Role, IsSuperuser: defaultRole.IsSuperuser}
+	}
+	if err := superuser.CheckLockout(defaultRole, desired); err != nil {
+		return err
+	}
+	if l.Drop {
+		return superuser.DeleteRole(defaultRole)
+	}
+	return superuser.setRoleLogin(l.DefaultRole, false)
+}
+
+// IsLockedDown reports whether the replacement superuser is a superuser that
+// can log in and the default role can no longer log in, or is gone when Drop
+// is set.
+func (c *Cluster) IsLockedDown(l Lockdown) (bool, error) {
+	superuser, err := c.GetRole(l.Superuser)
+	if errors.Is(err, ErrRoleNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if !superuser.IsSuperuser || !superuser.CanLogin {
+		return false, nil
+	}
+
+	defaultRole, err := c.GetRole(l.DefaultRole)
+	if errors.Is(err, ErrRoleNotFound) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return !l.Drop && !defaultRole.CanLogin, nil
+}
+
+// Unlock gives the login back to the default role. It returns
+// ErrRoleNotFound when the default role was dropped, since its password
+// cannot be restored.
+func (c *Cluster) Unlock(l Lockdown) error {
+	defaultRole, err := c.GetRole(l.DefaultRole)
+	if err != nil {
+		return err
+	}
+	if defaultRole.CanLogin {
+		return nil
+	}
+	return c.setRoleLogin(l.DefaultRole, true)
+}
+
+// VerifyLogin opens a new session to the cluster as username to check that
+// the credentials are accepted.
+func (c *Cluster) VerifyLogin(username, password string) error {
+	cluster, err := c.loginAs(username, password)
+	if err != nil {
+		return err
+	}
+	cluster.Session.Close()
+	return nil
+}
+
+// loginAs returns a copy of the cluster with a new session logged in as
+// username. The caller closes the session.
+func (c *Cluster) loginAs(username, password string) (*Cluster, error) {
+	config := *c.Cluster
+	config.Authenticator = gocql.PasswordAuthenticator{
+		Username: username,
+		Password: password,
+	}
+	session, err := config.CreateSession()
+	if err != nil {
+		return nil, err
+	}
+	return &Cluster{
+		Cluster:                &config,
+		SystemAuthKeyspaceName: c.SystemAuthKeyspaceName,
+		Session:                session,
+		Username:               username,
+	}, nil
+}
+
+// ensureSuperuser creates name as a superuser that can log in with password,
+// or updates an existing role to match. The superuser status of an existing
+// superuser is left alone, since a role cannot alter its own.
+func (c *Cluster) ensureSuperuser(name, password string) error {
+	role, err := c.GetRole(name)
+	if errors.Is(err, ErrRoleNotFound) {
+		return c.CreateRole(Role{Role: name, CanLogin: true, IsSuperuser: true, Password: password})
+	}
+	if err != nil {
+		return err
+	}
+	options := []option{stringOption("PASSWORD", password), boolOption("LOGIN", true)}
+	if !role.IsSuperuser {
+		options = append(options, boolOption("SUPERUSER", true))
+	}
+	return c.Session.Query(newStatement("ALTER ROLE").literal(name).with(options...).String()).Exec()
+}
+
+// setRoleLogin changes only the login of a role, which unlike its superuser
+// status may be altered by the role itself.
+func (c *Cluster) setRoleLogin(name string, canLogin bool) error {
+	return c.Session.Query(newStatement("ALTER ROLE").literal(name).with(boolOption("LOGIN", canLogin)).String()).Exec()
+}
@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package scylladb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockdownValidate(t *testing.T) {
+	assert.NoError(t, Lockdown{DefaultRole: DefaultSuperuser, Superuser: "admin", Password: "secret"}.validate())
+	assert.Error(t, Lockdown{DefaultRole: DefaultSuperuser, Superuser: DefaultSuperuser, Password: "secret"}.validate())
+	assert.Error(t, Lockdown{DefaultRole: DefaultSuperuser, Superuser: "admin"}.validate())
+	assert.Error(t, Lockdown{DefaultRole: DefaultSuperuser, Password: "secret"}.validate())
+}
+
+func TestLockDown(t *testing.T) {
+	cluster := newTestCluster(t)
+	defer cluster.Session.Close()
+
+	lockdown := Lockdown{DefaultRole: DefaultSuperuser, Superuser: "admin", Password: "admin-secret"}
+	locked, err := cluster.IsLockedDown(lockdown)
+	assert.NoError(t, err)
+	assert.False(t, locked)
+
+	// An existing superuser gets the configured password
+	assert.NoError(t, cluster.CreateRole(Role{Role: "admin", CanLogin: true, IsSuperuser: true, Password: "other"}))
+	assert.Error(t, cluster.VerifyLogin("admin", "admin-secret"))
+
+	assert.NoError(t, cluster.LockDown(lockdown))
+	assert.NoError(t, cluster.VerifyLogin("admin", "admin-secret"))
+	assert.Error(t, cluster.VerifyLogin(DefaultSuperuser, "cassandra"))
+	locked, err = cluster.IsLockedDown(lockdown)
+	assert.NoError(t, err)
+	assert.True(t, locked)
+
+	// Locking down again changes nothing
+	assert.NoError(t, cluster.LockDown(lockdown))
+	locked, err = cluster.IsLockedDown(lockdown)
+	assert.NoError(t, err)
+	assert.True(t, locked)
+
+	assert.NoError(t, cluster.Unlock(lockdown))
+	assert.NoError(t, cluster.VerifyLogin(DefaultSuperuser, "cassandra"))
+	locked, err = cluster.IsLockedDown(lockdown)
+	assert.NoError(t, err)
+	assert.False(t, locked)
+
+	// Dropping the default role cannot be undone. The cluster is logged in
+	// as the default role, so the drop runs as the replacement superuser.
+	lockdown.Drop = true
+	assert.NoError(t, cluster.LockDown(lockdown))
+	assert.Error(t, cluster.VerifyLogin(DefaultSuperuser, "cassandra"))
+	_, err = cluster.GetRole(DefaultSuperuser)
+	assert.ErrorIs(t, err, ErrRoleNotFound)
+	locked, err = cluster.IsLockedDown(lockdown)
+	assert.NoError(t, err)
+	assert.True(t, locked)
+	assert.ErrorIs(t, cluster.Unlock(lockdown), ErrRoleNotFound)
+}
@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package scylladb
+
+import "fmt"
+
+// CountSuperusers returns the number of superuser roles that can log in.
+func (c *Cluster) CountSuperusers() (int, error) {
+	roles, err := c.ListRoles()
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, role := range roles {
+		if role.IsSuperuser && role.CanLogin {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// CheckLockout returns ErrLockout when changing the role current into
+// desired, or dropping it when desired is nil, would take away the login or
+// the superuser status of the role the session authenticates as, or would
+// leave the cluster without a superuser that can log in.
+func (c *Cluster) CheckLockout(current Role, desired *Role) error {
+	if reason := selfLockoutReason(current, desired, c.Username); reason != "" {
+		return fmt.Errorf("%w: %s", ErrLockout, reason)
+	}
+	if !current.IsSuperuser || !current.CanLogin {
+		return nil
+	}
+	if desired != nil && desired.IsSuperuser && desired.CanLogin {
+		return nil
+	}
+	count, err := c.CountSuperusers()
+	if err != nil {
+		return err
+	}
+	if count <= 1 {
+		return fmt.Errorf("%w: %s is the last superuser that can log in", ErrLockout, current.Role)
+	}
+	return nil
+}
+
+// selfLockoutReason describes how the change would lock out the role
+// username, or returns an empty string when it would not.
+func selfLockoutReason(current Role, desired *Role, username string) string {
+	if username == "" || current.Role != username {
+		return ""
+	}
+	switch {
+	case desired == nil:
+		return fmt.Sprintf("%s is the role the provider is authenticated as and cannot be dropped", current.Role)
+	case current.CanLogin && !desired.CanLogin:
+		return fmt.Sprintf("%s is the role the provider is authenticated as and cannot lose its login", current.Role)
+	case current.IsSuperuser && !desired.IsSuperuser:
+		return fmt.Sprintf("%s is the role the provider is authenticated as and cannot lose its superuser status", current.Role)
+	}
+	return ""
+}
@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package scylladb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelfLockoutReason(t *testing.T) {
+	admin := Role{Role: "admin", CanLogin: true, IsSuperuser: true}
+
+	assert.NotEmpty(t, selfLockoutReason(admin, nil, "admin"))
+	assert.NotEmpty(t, selfLockoutReason(admin, &Role{Role: "admin", IsSuperuser: true}, "admin"))
+	assert.NotEmpty(t, selfLockoutReason(admin, &Role{Role: "admin", CanLogin: true}, "admin"))
+	assert.Empty(t, selfLockoutReason(admin, &admin, "admin"))
+	assert.Empty(t, selfLockoutReason(admin, nil, "other"))
+	assert.Empty(t, selfLockoutReason(admin, nil, ""))
+
+	// A role that could not log in is not locked out any further
+	assert.Empty(t, selfLockoutReason(Role{Role: "admin"}, &Role{Role: "admin"}, "admin"))
+}
+
+func TestCheckLockout(t *testing.T) {
+	cluster := newTestCluster(t)
+	defer cluster.Session.Close()
+
+	cassandra, err := cluster.GetRole("cassandra")
+	if err != nil {
+		t.Fatalf("failed to read the role: %s", err)
+	}
+
+	// The provider cannot drop or demote the role it is authenticated as
+	assert.ErrorIs(t, cluster.CheckLockout(cassandra, nil), ErrLockout)
+	assert.ErrorIs(t, cluster.CheckLockout(cassandra, &Role{Role: "cassandra", CanLogin: false, IsSuperuser: true}), ErrLockout)
+
+	// Nor the last superuser that can log in, whoever it authenticates as
+	cluster.Username = "someone-else"
+	assert.ErrorIs(t, cluster.CheckLockout(cassandra, nil), ErrLockout)
+
+	if err := cluster.CreateRole(Role{Role: "admin", CanLogin: true, IsSuperuser: true}); err != nil {
+		t.Fatalf("failed to create a role: %s", err)
+	}
+	count, err := cluster.CountSuperusers()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.NoError(t, cluster.CheckLockout(cassandra, nil))
+	assert.NoError(t, cluster.CheckLockout(Role{Role: "reader"}, nil))
+}
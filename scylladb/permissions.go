@@ -0,0 +1,415 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package scylladb
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ResourceType is the kind of resource a permission applies to.
+type ResourceType string
+
+const (
+	ResourceAllKeyspaces ResourceType = "all_keyspaces"
+	ResourceKeyspace     ResourceType = "keyspace"
+	ResourceTable        ResourceType = "table"
+	ResourceAllRoles     ResourceType = "all_roles"
+	ResourceRole         ResourceType = "role"
+	// ResourceAllFunctions covers every function, or every function of a
+	// keyspace when Resource.Keyspace is set.
+	ResourceAllFunctions ResourceType = "all_functions"
+	ResourceFunction     ResourceType = "function"
+)
+
+// ResourceTypes lists every supported resource type.
+var ResourceTypes = []ResourceType{
+	ResourceAllKeyspaces,
+	ResourceKeyspace,
+	ResourceTable,
+	ResourceAllRoles,
+	ResourceRole,
+	ResourceAllFunctions,
+	ResourceFunction,
+}
+
+// Permissions lists every permission that can be granted.
+var Permissions = []string{"CREATE", "ALTER", "DROP", "SELECT", "MODIFY", "AUTHORIZE", "DESCRIBE", "EXECUTE"}
+
+// resourcePermissions lists the permissions that apply to each resource type.
+var resourcePermissions = map[ResourceType][]string{
+	ResourceAllKeyspaces: {"CREATE", "ALTER", "DROP", "SELECT", "MODIFY", "AUTHORIZE"},
+	ResourceKeyspace:     {"CREATE", "ALTER", "DROP", "SELECT", "MODIFY", "AUTHORIZE"},
+	ResourceTable:        {"ALTER", "DROP", "SELECT", "MODIFY", "AUTHORIZE"},
+	ResourceAllRoles:     {"CREATE", "ALTER", "DROP", "AUTHORIZE", "DESCRIBE"},
+	ResourceRole:         {"ALTER", "DROP", "AUTHORIZE"},
+	ResourceAllFunctions: {"CREATE", "ALTER", "DROP", "AUTHORIZE", "EXECUTE"},
+	ResourceFunction:     {"ALTER", "DROP", "AUTHORIZE", "EXECUTE"},
+}
+
+// Resource identifies what a permission applies to. Only the fields that
+// belong to Type are used.
+type Resource struct {
+	Type     ResourceType
+	Keyspace string
+	Table    string
+	Role     string
+	// Function is the name of the function and FunctionArguments the CQL
+	// types of its arguments.
+	Function          string
+	FunctionArguments []string
+}
+
+// Validate checks that the fields required by the resource type are set
+// and that no other field is.
+func (r Resource) Validate() error {
+	var required, allowed []string
+	switch r.Type {
+	case ResourceAllKeyspaces, ResourceAllRoles:
+	case ResourceKeyspace:
+		required = []string{"keyspace"}
+	case ResourceTable:
+		required = []string{"keyspace", "table"}
+	case ResourceRole:
+		required = []string{"role"}
+	case ResourceAllFunctions:
+		allowed = []string{"keyspace"}
+	case ResourceFunction:
+		required = []string{"keyspace", "function"}
+		allowed = []string{"function_arguments"}
+		for _, arg := range r.FunctionArguments {
+			if _, err := ParseType(arg); err != nil {
+				return fmt.Errorf("invalid function argument: %w", err)
+			}
+		}
+	default:
+		return fmt.Errorf("unknown resource type %q", r.Type)
+	}
+
+	set := map[string]bool{
+		"keyspace":           r.Keyspace != "",
+		"table":              r.Table != "",
+		"role":               r.Role != "",
+		"function":           r.Function != "",
+		"function_arguments": len(r.FunctionArguments) > 0,
+	}
+	for _, field := range required {
+		if !set[field] {
+			return fmt.Errorf("%s is required for resource type %s", field, r.Type)
+		}
+	}
+	for field, isSet := range set {
+		if isSet && !slices.Contains(required, field) && !slices.Contains(allowed, field) {
+			return fmt.Errorf("%s cannot be set for resource type %s", field, r.Type)
+		}
+	}
+	return nil
+}
+
+// String returns the resource the way LIST PERMISSIONS displays it, e.g.
+// `<table ks.users>`.
+func (r Resource) String() string {
+	switch r.Type {
+	case ResourceAllKeyspaces:
+		return "<all keyspaces>"
+	case ResourceKeyspace:
+		return fmt.Sprintf("<keyspace %s>", r.Keyspace)
+	case ResourceTable:
+		return fmt.Sprintf("<table %s.%s>", r.Keyspace, r.Table)
+	case ResourceAllRoles:
+		return "<all roles>"
+	case ResourceRole:
+		return fmt.Sprintf("<role %s>", r.Role)
+	case ResourceAllFunctions:
+		if r.Keyspace != "" {
+			return fmt.Sprintf("<all functions in %s>", r.Keyspace)
+		}
+		return "<all functions>"
+	case ResourceFunction:
+		return fmt.Sprintf("<function %s.%s(%s)>", r.Keyspace, r.Function, strings.Join(r.FunctionArguments, ", "))
+	}
+	return fmt.Sprintf("<%s>", r.Type)
+}
+
+// cql renders the resource as it appears in GRANT, REVOKE and LIST.
+func (r Resource) cql() string {
+	switch r.Type {
+	case ResourceAllKeyspaces:
+		return "ALL KEYSPACES"
+	case ResourceKeyspace:
+		return "KEYSPACE " + QuoteIdentifier(r.Keyspace)
+	case ResourceTable:
+		return "TABLE " + QuoteIdentifier(r.Keyspace) + "." + QuoteIdentifier(r.Table)
+	case ResourceAllRoles:
+		return "ALL ROLES"
+	case ResourceRole:
+		return "ROLE " + QuoteString(r.Role)
+	case ResourceAllFunctions:
+		if r.Keyspace != "" {
+			return "ALL FUNCTIONS IN KEYSPACE " + QuoteIdentifier(r.Keyspace)
+		}
+		return "ALL FUNCTIONS"
+	case ResourceFunction:
+		args := make([]string, 0, len(r.FunctionArguments))
+		for _, arg := range r.FunctionArguments {
+			if t, err := ParseType(arg); err == nil {
+				arg = t.String()
+			}
+			args = append(args, arg)
+		}
+		return "FUNCTION " + QuoteIdentifier(r.Keyspace) + "." + QuoteIdentifier(r.Function) +
+			"(" + strings.Join(args, ", ") + ")"
+	}
+	return ""
+}
+
+// equal reports whether two resources designate the same object. Function
+// argument types are compared with equalArgumentTypes.
+func (r Resource) equal(other Resource) bool {
+	if r.Type != other.Type || r.Keyspace != other.Keyspace || r.Table != other.Table ||
+		r.Role != other.Role || r.Function != other.Function ||
+		len(r.FunctionArguments) != len(other.FunctionArguments) {
+		return false
+	}
+	for i := range r.FunctionArguments {
+		if !equalArgumentTypes(r.FunctionArguments[i], other.FunctionArguments[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// equalArgumentTypes reports whether two function argument types are the
+// same. Function arguments are always frozen, so `list<int>` is the
+// `frozen<list<int>>` that LIST PERMISSIONS displays. Types that do not
+// parse are compared like EqualTypes does.
+func equalArgumentTypes(a, b string) bool {
+	ta, errA := ParseType(a)
+	tb, errB := ParseType(b)
+	if errA != nil || errB != nil {
+		return EqualTypes(a, b)
+	}
+	return ta.canonical(true).String() == tb.canonical(true).String()
+}
+
+// ParseResource parses a resource as displayed by LIST PERMISSIONS.
+func ParseResource(s string) (Resource, error) {
+	if !strings.HasPrefix(s, "<") || !strings.HasSuffix(s, ">") {
+		return Resource{}, fmt.Errorf("invalid resource %q", s)
+	}
+	body := s[1 : len(s)-1]
+	switch {
+	case body == "all keyspaces":
+		return Resource{Type: ResourceAllKeyspaces}, nil
+	case body == "all roles":
+		return Resource{Type: ResourceAllRoles}, nil
+	case body == "all functions":
+		return Resource{Type: ResourceAllFunctions}, nil
+	case strings.HasPrefix(body, "all functions in keyspace "):
+		return Resource{Type: ResourceAllFunctions, Keyspace: strings.TrimPrefix(body, "all functions in keyspace ")}, nil
+	case strings.HasPrefix(body, "all functions in "):
+		return Resource{Type: ResourceAllFunctions, Keyspace: strings.TrimPrefix(body, "all functions in ")}, nil
+	case strings.HasPrefix(body, "keyspace "):
+		return Resource{Type: ResourceKeyspace, Keyspace: strings.TrimPrefix(body, "keyspace ")}, nil
+	case strings.HasPrefix(body, "table "):
+		keyspace, table, ok := strings.Cut(strings.TrimPrefix(body, "table "), ".")
+		if !ok {
+			return Resource{}, fmt.Errorf("invalid table resource %q", s)
+		}
+		return Resource{Type: ResourceTable, Keyspace: keyspace, Table: table}, nil
+	case strings.HasPrefix(body, "role "):
+		return Resource{Type: ResourceRole, Role: strings.TrimPrefix(body, "role ")}, nil
+	case strings.HasPrefix(body, "function "):
+		signature := strings.TrimPrefix(body, "function ")
+		keyspace, rest, ok := strings.Cut(signature, ".")
+		open := strings.Index(rest, "(")
+		if !ok || open < 0 || !strings.HasSuffix(rest, ")") {
+			return Resource{}, fmt.Errorf("invalid function resource %q", s)
+		}
+		return Resource{
+			Type:              ResourceFunction,
+			Keyspace:          keyspace,
+			Function:          strings.Trim(rest[:open], `"`),
+			FunctionArguments: splitTopLevel(rest[open+1 : len(rest)-1]),
+		}, nil
+	}
+	return Resource{}, fmt.Errorf("unsupported resource %q", s)
+}
+
+// splitTopLevel splits a comma separated list of CQL types, ignoring the
+// commas nested in angle brackets.
+func splitTopLevel(s string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, r := range s {
+		switch r {
+		case '<':
+			depth++
+		case '>':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	if last := strings.TrimSpace(s[start:]); last != "" {
+		parts = append(parts, last)
+	}
+	return parts
+}
+
+// ValidatePermission checks that permission can be granted on resource
+// type.
+func ValidatePermission(permission string, resourceType ResourceType) error {
+	allowed, ok := resourcePermissions[resourceType]
+	if !ok {
+		return fmt.Errorf("unknown resource type %q", resourceType)
+	}
+	if !slices.Contains(allowed, permission) {
+		return fmt.Errorf("permission %s cannot be granted on resource type %s, expected one of %s",
+			permission, resourceType, strings.Join(allowed, ", "))
+	}
+	return nil
+}
+
+// Permission is a permission held by a role on a resource.
+type Permission struct {
+	Role       string
+	Permission string
+	Resource   Resource
+}
+
+func (p Permission) validate() error {
+	if err := validateRoleName(p.Role); err != nil {
+		return err
+	}
+	if err := p.Resource.Validate(); err != nil {
+		return err
+	}
+	return ValidatePermission(p.Permission, p.Resource.Type)
+}
+
+// GrantPermission grants the permission on its resource to its role.
+func (c *Cluster) GrantPermission(p Permission) error {
+	if err := p.validate(); err != nil {
+		return err
+	}
+	query := newStatement("GRANT", p.Permission, "ON", p.Resource.cql(), "TO").literal(p.Role).String()
+	return c.Session.Query(query).Exec()
+}
+
+// RevokePermission revokes the permission on its resource from its role.
+func (c *Cluster) RevokePermission(p Permission) error {
+	if err := p.validate(); err != nil {
+		return err
+	}
+	query := newStatement("REVOKE", p.Permission, "ON", p.Resource.cql(), "FROM").literal(p.Role).String()
+	return c.Session.Query(query).Exec()
+}
+
+// HasPermission reports whether the role of p was directly granted the
+// permission, ignoring permissions inherited from other roles. It returns
+// ErrRoleNotFound when the role does not exist.
+func (c *Cluster) HasPermission(p Permission) (bool, error) {
+	permissions, err := c.ListPermissions(p.Role, false)
+	if err != nil {
+		return false, err
+	}
+	return slices.ContainsFunc(permissions, p.Equal), nil
+}
+
+// ListPermissions returns the permissions of role as reported by LIST ALL
+// PERMISSIONS. When recursive is set, the permissions the role inherits are
+// included and their Role is the role that holds the grant. A permission on
+// a resource this package does not model is an error rather than left out,
+// so that no grant goes unnoticed. The permissions are sorted by role,
+// resource and permission. It returns ErrRoleNotFound when the role does
+// not exist.
+func (c *Cluster) ListPermissions(role string, recursive bool) ([]Permission, error) {
+	if _, err := c.GetRole(role); err != nil {
+		return nil, err
+	}
+	stmt := newStatement("LIST ALL PERMISSIONS OF").literal(role)
+	if !recursive {
+		stmt.keyword("NORECURSIVE")
+	}
+	iter := c.Session.Query(stmt.String()).Iter()
+	var permissions []Permission
+	var grantee, username, resource, permission string
+	for iter.Scan(&grantee, &username, &resource, &permission) {
+		r, err := ParseResource(resource)
+		if err != nil {
+			_ = iter.Close()
+			return nil, fmt.Errorf("cannot read the %s permission of %s: %w", permission, grantee, err)
+		}
+		permissions = append(permissions, Permission{Role: grantee, Permission: permission, Resource: r})
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(permissions, func(a, b Permission) int {
+		return cmp.Or(
+			strings.Compare(a.Role, b.Role),
+			strings.Compare(a.Resource.String(), b.Resource.String()),
+			strings.Compare(a.Permission, b.Permission),
+		)
+	})
+	return permissions, nil
+}
+
+// SyncRolePermissions makes desired the exact set of permissions directly
+// granted to role, revoking every other permission the role holds. Each
+// desired permission must belong to role.
+func (c *Cluster) SyncRolePermissions(role string, desired []Permission) error {
+	for _, p := range desired {
+		if p.Role != role {
+			return fmt.Errorf("permission %s on %s belongs to %s, not %s", p.Permission, p.Resource, p.Role, role)
+		}
+		if err := p.validate(); err != nil {
+			return err
+		}
+	}
+	current, err := c.ListPermissions(role, false)
+	if err != nil {
+		return err
+	}
+	grants, revokes := DiffPermissions(current, desired)
+	for _, p := range revokes {
+		if err := c.RevokePermission(p); err != nil {
+			return fmt.Errorf("failed to revoke %s on %s from %s: %w", p.Permission, p.Resource, p.Role, err)
+		}
+	}
+	for _, p := range grants {
+		if err := c.GrantPermission(p); err != nil {
+			return fmt.Errorf("failed to grant %s on %s to %s: %w", p.Permission, p.Resource, p.Role, err)
+		}
+	}
+	return nil
+}
+
+// DiffPermissions returns the minimal set of permissions to grant and to
+// revoke to turn current into desired. Duplicates are ignored.
+func DiffPermissions(current, desired []Permission) (grants, revokes []Permission) {
+	for _, p := range desired {
+		if !slices.ContainsFunc(current, p.Equal) && !slices.ContainsFunc(grants, p.Equal) {
+			grants = append(grants, p)
+		}
+	}
+	for _, p := range current {
+		if !slices.ContainsFunc(desired, p.Equal) && !slices.ContainsFunc(revokes, p.Equal) {
+			revokes = append(revokes, p)
+		}
+	}
+	return grants, revokes
+}
+
+// Equal reports whether two permissions grant the same thing to the same
+// role.
+func (p Permission) Equal(other Permission) bool {
+	return p.Role == other.Role && p.Permission == other.Permission && p.Resource.equal(other.Resource)
+}
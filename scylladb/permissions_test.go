@@ -0,0 +1,251 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package scylladb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceValidate(t *testing.T) {
+	valid := []Resource{
+		{Type: ResourceAllKeyspaces},
+		{Type: ResourceKeyspace, Keyspace: "ks"},
+		{Type: ResourceTable, Keyspace: "ks", Table: "users"},
+		{Type: ResourceAllRoles},
+		{Type: ResourceRole, Role: "reader"},
+		{Type: ResourceAllFunctions},
+		{Type: ResourceAllFunctions, Keyspace: "ks"},
+		{Type: ResourceFunction, Keyspace: "ks", Function: "fn"},
+		{Type: ResourceFunction, Keyspace: "ks", Function: "fn", FunctionArguments: []string{"int", "frozen<map<text, int>>"}},
+	}
+	for _, r := range valid {
+		assert.NoError(t, r.Validate(), r.String())
+	}
+
+	invalid := []Resource{
+		{Type: "table_family"},
+		{Type: ResourceKeyspace},
+		{Type: ResourceTable, Keyspace: "ks"},
+		{Type: ResourceAllKeyspaces, Keyspace: "ks"},
+		{Type: ResourceRole, Role: "reader", Keyspace: "ks"},
+		{Type: ResourceAllRoles, Role: "reader"},
+		{Type: ResourceFunction, Keyspace: "ks"},
+		{Type: ResourceFunction, Keyspace: "ks", Function: "fn", FunctionArguments: []string{"int) ON ALL KEYSPACES TO x; --"}},
+	}
+	for _, r := range invalid {
+		assert.Error(t, r.Validate(), r.String())
+	}
+}
+
+func TestValidatePermission(t *testing.T) {
+	assert.NoError(t, ValidatePermission("SELECT", ResourceTable))
+	assert.NoError(t, ValidatePermission("CREATE", ResourceKeyspace))
+	assert.NoError(t, ValidatePermission("DESCRIBE", ResourceAllRoles))
+	assert.NoError(t, ValidatePermission("EXECUTE", ResourceFunction))
+	assert.Error(t, ValidatePermission("CREATE", ResourceTable))
+	assert.Error(t, ValidatePermission("SELECT", ResourceRole))
+	assert.Error(t, ValidatePermission("DESCRIBE", ResourceRole))
+	assert.Error(t, ValidatePermission("EXECUTE", ResourceKeyspace))
+	assert.Error(t, ValidatePermission("select", ResourceTable))
+	assert.Error(t, ValidatePermission("SELECT", "unknown"))
+
+	for _, resourceType := range ResourceTypes {
+		assert.NotEmpty(t, resourcePermissions[resourceType], resourceType)
+	}
+}
+
+func TestResourceCQL(t *testing.T) {
+	tests := map[string]Resource{
+		`ALL KEYSPACES`:                  {Type: ResourceAllKeyspaces},
+		`KEYSPACE "ks"`:                  {Type: ResourceKeyspace, Keyspace: "ks"},
+		`TABLE "my""ks"."Users"`:         {Type: ResourceTable, Keyspace: `my"ks`, Table: "Users"},
+		`ALL ROLES`:                      {Type: ResourceAllRoles},
+		`ROLE 'it''s'`:                   {Type: ResourceRole, Role: "it's"},
+		`ALL FUNCTIONS`:                  {Type: ResourceAllFunctions},
+		`ALL FUNCTIONS IN KEYSPACE "ks"`: {Type: ResourceAllFunctions, Keyspace: "ks"},
+		`FUNCTION "ks"."fn"(int, text)`:  {Type: ResourceFunction, Keyspace: "ks", Function: "fn", FunctionArguments: []string{"INT", "varchar"}},
+		`FUNCTION "ks"."no_args"()`:      {Type: ResourceFunction, Keyspace: "ks", Function: "no_args"},
+	}
+	for expected, r := range tests {
+		assert.Equal(t, expected, r.cql())
+	}
+}
+
+func TestParseResource(t *testing.T) {
+	resources := []Resource{
+		{Type: ResourceAllKeyspaces},
+		{Type: ResourceKeyspace, Keyspace: "ks"},
+		{Type: ResourceTable, Keyspace: "ks", Table: "users"},
+		{Type: ResourceAllRoles},
+		{Type: ResourceRole, Role: "app-reader"},
+		{Type: ResourceAllFunctions},
+		{Type: ResourceAllFunctions, Keyspace: "ks"},
+		{Type: ResourceFunction, Keyspace: "ks", Function: "fn", FunctionArguments: []string{"int", "map<text, int>"}},
+	}
+	for _, r := range resources {
+		parsed, err := ParseResource(r.String())
+		assert.NoError(t, err, r.String())
+		assert.True(t, r.equal(parsed), r.String())
+	}
+
+	parsed, err := ParseResource("<all functions in keyspace ks>")
+	assert.NoError(t, err)
+	assert.Equal(t, Resource{Type: ResourceAllFunctions, Keyspace: "ks"}, parsed)
+
+	parsed, err = ParseResource("<function ks.fn()>")
+	assert.NoError(t, err)
+	assert.True(t, Resource{Type: ResourceFunction, Keyspace: "ks", Function: "fn"}.equal(parsed))
+
+	for _, s := range []string{"", "keyspace ks", "<table ks>", "<service_level sl>", "<function ks.fn>"} {
+		_, err := ParseResource(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestResourceEqual(t *testing.T) {
+	a := Resource{Type: ResourceFunction, Keyspace: "ks", Function: "fn", FunctionArguments: []string{"Map<text,int>"}}
+	b := Resource{Type: ResourceFunction, Keyspace: "ks", Function: "fn", FunctionArguments: []string{"map<text, int>"}}
+	assert.True(t, a.equal(b))
+	equal := [][2]string{
+		{"text", "varchar"},
+		{"list<int>", "frozen<list<int>>"},
+		{"frozen<map<VARCHAR, frozen<set<int>>>>", "map<text, frozen<set<int>>>"},
+		{"tuple<int, text>", "frozen<tuple<int, varchar>>"},
+	}
+	for _, types := range equal {
+		a.FunctionArguments, b.FunctionArguments = []string{types[0]}, []string{types[1]}
+		assert.True(t, a.equal(b), types)
+	}
+	a.FunctionArguments, b.FunctionArguments = []string{"list<int>"}, []string{"set<int>"}
+	assert.False(t, a.equal(b))
+	assert.False(t, a.equal(Resource{Type: ResourceFunction, Keyspace: "ks", Function: "fn"}))
+	assert.False(t, Resource{Type: ResourceKeyspace, Keyspace: "a"}.equal(Resource{Type: ResourceKeyspace, Keyspace: "b"}))
+}
+
+func TestGrantPermission(t *testing.T) {
+	cluster := newTestCluster(t)
+	defer cluster.Session.Close()
+
+	if err := cluster.Session.Query(`CREATE KEYSPACE ks WITH replication = {'class': 'NetworkTopologyStrategy', 'replication_factor': 1}`).Exec(); err != nil {
+		t.Fatalf("failed to create a keyspace: %s", err)
+	}
+	if err := cluster.CreateRole(Role{Role: "app-reader"}); err != nil {
+		t.Fatalf("failed to create a role: %s", err)
+	}
+
+	permissions := []Permission{
+		{Role: "app-reader", Permission: "SELECT", Resource: Resource{Type: ResourceKeyspace, Keyspace: "ks"}},
+		{Role: "app-reader", Permission: "CREATE", Resource: Resource{Type: ResourceAllKeyspaces}},
+		{Role: "app-reader", Permission: "DESCRIBE", Resource: Resource{Type: ResourceAllRoles}},
+		{Role: "app-reader", Permission: "ALTER", Resource: Resource{Type: ResourceRole, Role: "cassandra"}},
+		{Role: "app-reader", Permission: "EXECUTE", Resource: Resource{Type: ResourceAllFunctions, Keyspace: "ks"}},
+	}
+	for _, p := range permissions {
+		granted, err := cluster.HasPermission(p)
+		assert.NoError(t, err)
+		assert.False(t, granted, p.Resource.String())
+
+		if err := cluster.GrantPermission(p); err != nil {
+			t.Fatalf("failed to grant %s on %s: %s", p.Permission, p.Resource, err)
+		}
+		granted, err = cluster.HasPermission(p)
+		assert.NoError(t, err)
+		assert.True(t, granted, p.Resource.String())
+
+		if err := cluster.RevokePermission(p); err != nil {
+			t.Fatalf("failed to revoke %s on %s: %s", p.Permission, p.Resource, err)
+		}
+		granted, err = cluster.HasPermission(p)
+		assert.NoError(t, err)
+		assert.False(t, granted, p.Resource.String())
+	}
+
+	_, err := cluster.HasPermission(Permission{Role: "missing", Permission: "SELECT", Resource: Resource{Type: ResourceAllKeyspaces}})
+	assert.ErrorIs(t, err, ErrRoleNotFound)
+
+	err = cluster.GrantPermission(Permission{Role: "app-reader", Permission: "CREATE", Resource: Resource{Type: ResourceTable, Keyspace: "ks", Table: "t"}})
+	assert.Error(t, err)
+}
+
+func TestDiffPermissions(t *testing.T) {
+	selectKeyspace := Permission{Role: "app", Permission: "SELECT", Resource: Resource{Type: ResourceKeyspace, Keyspace: "ks"}}
+	modifyTable := Permission{Role: "app", Permission: "MODIFY", Resource: Resource{Type: ResourceTable, Keyspace: "ks", Table: "t"}}
+	describeRoles := Permission{Role: "app", Permission: "DESCRIBE", Resource: Resource{Type: ResourceAllRoles}}
+	executeFunction := Permission{Role: "app", Permission: "EXECUTE", Resource: Resource{Type: ResourceFunction, Keyspace: "ks", Function: "fn", FunctionArguments: []string{"Map<text,int>"}}}
+
+	grants, revokes := DiffPermissions(
+		[]Permission{selectKeyspace, describeRoles, executeFunction},
+		[]Permission{selectKeyspace, modifyTable, modifyTable, {Role: "app", Permission: "EXECUTE", Resource: Resource{Type: ResourceFunction, Keyspace: "ks", Function: "fn", FunctionArguments: []string{"map<text, int>"}}}},
+	)
+	assert.Equal(t, []Permission{modifyTable}, grants)
+	assert.Equal(t, []Permission{describeRoles}, revokes)
+
+	grants, revokes = DiffPermissions(nil, nil)
+	assert.Empty(t, grants)
+	assert.Empty(t, revokes)
+}
+
+func TestSyncRolePermissions(t *testing.T) {
+	cluster := newTestCluster(t)
+	defer cluster.Session.Close()
+
+	if err := cluster.Session.Query(`CREATE KEYSPACE ks WITH replication = {'class': 'NetworkTopologyStrategy', 'replication_factor': 1}`).Exec(); err != nil {
+		t.Fatalf("failed to create a keyspace: %s", err)
+	}
+	if err := cluster.CreateRole(Role{Role: "service"}); err != nil {
+		t.Fatalf("failed to create a role: %s", err)
+	}
+
+	selectKeyspace := Permission{Role: "service", Permission: "SELECT", Resource: Resource{Type: ResourceKeyspace, Keyspace: "ks"}}
+	describeRoles := Permission{Role: "service", Permission: "DESCRIBE", Resource: Resource{Type: ResourceAllRoles}}
+	if err := cluster.GrantPermission(describeRoles); err != nil {
+		t.Fatalf("failed to grant a permission: %s", err)
+	}
+
+	assert.NoError(t, cluster.SyncRolePermissions("service", []Permission{selectKeyspace}))
+	permissions, err := cluster.ListPermissions("service", false)
+	assert.NoError(t, err)
+	assert.Len(t, permissions, 1)
+	assert.True(t, selectKeyspace.Equal(permissions[0]))
+
+	assert.NoError(t, cluster.SyncRolePermissions("service", nil))
+	permissions, err = cluster.ListPermissions("service", false)
+	assert.NoError(t, err)
+	assert.Empty(t, permissions)
+
+	assert.Error(t, cluster.SyncRolePermissions("service", []Permission{{Role: "other", Permission: "SELECT", Resource: Resource{Type: ResourceAllKeyspaces}}}))
+	assert.ErrorIs(t, cluster.SyncRolePermissions("missing", nil), ErrRoleNotFound)
+}
+
+func TestListPermissions(t *testing.T) {
+	cluster := newTestCluster(t)
+	defer cluster.Session.Close()
+
+	if err := cluster.CreateRole(Role{Role: "reader"}); err != nil {
+		t.Fatalf("failed to create a role: %s", err)
+	}
+	if err := cluster.CreateRole(Role{Role: "app", MemberOf: []string{"reader"}}); err != nil {
+		t.Fatalf("failed to create a role: %s", err)
+	}
+	describeRoles := Permission{Role: "reader", Permission: "DESCRIBE", Resource: Resource{Type: ResourceAllRoles}}
+	alterReader := Permission{Role: "app", Permission: "ALTER", Resource: Resource{Type: ResourceRole, Role: "reader"}}
+	for _, p := range []Permission{describeRoles, alterReader} {
+		if err := cluster.GrantPermission(p); err != nil {
+			t.Fatalf("failed to grant %s on %s: %s", p.Permission, p.Resource, err)
+		}
+	}
+
+	direct, err := cluster.ListPermissions("app", false)
+	assert.NoError(t, err)
+	assert.Equal(t, []Permission{alterReader}, direct)
+
+	inherited, err := cluster.ListPermissions("app", true)
+	assert.NoError(t, err)
+	assert.Equal(t, []Permission{alterReader, describeRoles}, inherited)
+
+	_, err = cluster.ListPermissions("missing", true)
+	assert.ErrorIs(t, err, ErrRoleNotFound)
+}
@@ -0,0 +1,292 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package scylladb
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReplicationChange is the change of the replication factor of a single data
+// center. DataCenter is empty for a SimpleStrategy keyspace, and a data
+// center that is added or removed goes from or to zero. FromRacks and
+// ToRacks hold the racks of a data center that uses a rack list, in which
+// case its replication factor is the number of racks.
+type ReplicationChange struct {
+	DataCenter string
+	From       int
+	To         int
+	FromRacks  []string
+	ToRacks    []string
+}
+
+// IsDecrease reports whether the change removes replicas.
+func (c ReplicationChange) IsDecrease() bool {
+	return c.To < c.From
+}
+
+func (c ReplicationChange) String() string {
+	name := c.DataCenter
+	if name == "" {
+		name = "replication_factor"
+	}
+	return fmt.Sprintf("%s: %s -> %s", name, formatPlacement(c.From, c.FromRacks), formatPlacement(c.To, c.ToRacks))
+}
+
+func formatPlacement(rf int, racks []string) string {
+	if racks == nil {
+		return strconv.Itoa(rf)
+	}
+	return "[" + strings.Join(racks, ", ") + "]"
+}
+
+// placement is the replication of a single data center, either a
+// replication factor or a list of racks.
+type placement struct {
+	rf    int
+	racks []string
+}
+
+func (r Replication) placement(dc string) placement {
+	if racks, ok := r.Racks[dc]; ok {
+		return placement{rf: len(racks), racks: sortedRacks(racks)}
+	}
+	return placement{rf: r.DataCenters[dc]}
+}
+
+// withPlacement returns a copy of r where dc has the placement p, or no
+// replicas at all when p is empty.
+func (r Replication) withPlacement(dc string, p placement) Replication {
+	next := r
+	next.DataCenters = maps.Clone(r.DataCenters)
+	next.Racks = maps.Clone(r.Racks)
+	delete(next.DataCenters, dc)
+	delete(next.Racks, dc)
+	switch {
+	case p.racks != nil && len(p.racks) > 0:
+		if next.Racks == nil {
+			next.Racks = map[string][]string{}
+		}
+		next.Racks[dc] = p.racks
+	case p.racks == nil && p.rf > 0:
+		if next.DataCenters == nil {
+			next.DataCenters = map[string]int{}
+		}
+		next.DataCenters[dc] = p.rf
+	}
+	return next
+}
+
+// DiffReplication returns the replication changes between two replications
+// of the same class, sorted by data center. A data center whose racks change
+// is part of the changes even when its replication factor does not. It
+// returns nil when the classes differ.
+func DiffReplication(current, desired Replication) []ReplicationChange {
+	if NormalizeReplicationClass(current.Class) != NormalizeReplicationClass(desired.Class) {
+		return nil
+	}
+	if current.ReplicationFactor != desired.ReplicationFactor {
+		return []ReplicationChange{{From: current.ReplicationFactor, To: desired.ReplicationFactor}}
+	}
+	var dataCenters []string
+	for _, r := range []Replication{current, desired} {
+		dataCenters = append(dataCenters, slices.Collect(maps.Keys(r.DataCenters))...)
+		dataCenters = append(dataCenters, slices.Collect(maps.Keys(r.Racks))...)
+	}
+	slices.Sort(dataCenters)
+	dataCenters = slices.Compact(dataCenters)
+	var changes []ReplicationChange
+	for _, dc := range dataCenters {
+		from, to := current.placement(dc), desired.placement(dc)
+		if from.rf != to.rf || (from.racks == nil) != (to.racks == nil) || !slices.Equal(from.racks, to.racks) {
+			changes = append(changes, ReplicationChange{DataCenter: dc, From: from.rf, To: to.rf, FromRacks: from.racks, ToRacks: to.racks})
+		}
+	}
+	return changes
+}
+
+// ReplicationSteps returns the replications to apply, in order, to go from
+// current to desired while changing the replication factor of a single data
+// center by one at a time, as tablets keyspaces require. Increases come
+// before decreases so that redundancy never drops below the lower of both.
+// Rack lists gain their new racks one at a time before losing their old
+// ones, and a data center switches between a replication factor and a rack
+// list once both place the same number of replicas. A change of class is a
+// single step.
+func ReplicationSteps(current, desired Replication) []Replication {
+	if NormalizeReplicationClass(current.Class) != NormalizeReplicationClass(desired.Class) {
+		return []Replication{desired}
+	}
+	changes := DiffReplication(current, desired)
+	slices.SortStableFunc(changes, func(a, b ReplicationChange) int {
+		if a.IsDecrease() == b.IsDecrease() {
+			return 0
+		}
+		if a.IsDecrease() {
+			return 1
+		}
+		return -1
+	})
+
+	var steps []Replication
+	step := current
+	for _, change := range changes {
+		if change.DataCenter == "" {
+			for _, rf := range countSteps(change.From, change.To) {
+				step.ReplicationFactor = rf
+				steps = append(steps, step)
+			}
+			continue
+		}
+		for _, p := range placementSteps(placement{change.From, change.FromRacks}, placement{change.To, change.ToRacks}) {
+			step = step.withPlacement(change.DataCenter, p)
+			steps = append(steps, step)
+		}
+	}
+	return steps
+}
+
+// countSteps returns the replication factors between from, excluded, and
+// to, included.
+func countSteps(from, to int) []int {
+	var rfs []int
+	for rf := from; rf != to; {
+		if rf < to {
+			rf++
+		} else {
+			rf--
+		}
+		rfs = append(rfs, rf)
+	}
+	return rfs
+}
+
+// placementSteps returns the placements of a data center between from,
+// excluded, and to, included, adding or removing a single replica at a time.
+func placementSteps(from, to placement) []placement {
+	// A new data center gets its racks one at a time
+	if from.rf == 0 && to.racks != nil {
+		from.racks = []string{}
+	}
+	var steps []placement
+	switch {
+	case from.racks == nil && to.racks == nil:
+		for _, rf := range countSteps(from.rf, to.rf) {
+			steps = append(steps, placement{rf: rf})
+		}
+	case from.racks == nil:
+		// Reach the number of racks first, then name them
+		for _, rf := range countSteps(from.rf, to.rf) {
+			steps = append(steps, placement{rf: rf})
+		}
+		steps = append(steps, to)
+	case to.racks == nil && to.rf > 0:
+		// Drop the names of the racks first, then reach the replication factor
+		steps = append(steps, placement{rf: from.rf})
+		for _, rf := range countSteps(from.rf, to.rf) {
+			steps = append(steps, placement{rf: rf})
+		}
+	default:
+		racks := slices.Clone(from.racks)
+		for _, rack := range to.racks {
+			if !slices.Contains(racks, rack) {
+				racks = append(racks, rack)
+				steps = append(steps, placement{rf: len(racks), racks: sortedRacks(racks)})
+			}
+		}
+		for _, rack := range from.racks {
+			if !slices.Contains(to.racks, rack) {
+				racks = slices.DeleteFunc(racks, func(r string) bool { return r == rack })
+				steps = append(steps, placement{rf: len(racks), racks: sortedRacks(racks)})
+			}
+		}
+	}
+	return steps
+}
+
+// FormatReplicationChanges renders changes as a comma separated list.
+func FormatReplicationChanges(changes []ReplicationChange) string {
+	parts := make([]string, 0, len(changes))
+	for _, change := range changes {
+		parts = append(parts, change.String())
+	}
+	return strings.Join(parts, ", ")
+}
+
+// tabletMigrationPollInterval is how often alterReplicationStepwise checks
+// whether the tablets have settled.
+var tabletMigrationPollInterval = time.Second
+
+// alterReplicationStepwise alters the replication of a tablets keyspace one
+// step at a time, waiting for the cluster to agree on the schema and to
+// finish moving the tablets after each step. The last step also applies the
+// other options of keyspace. Waiting stops when ctx is done.
+func (c *Cluster) alterReplicationStepwise(ctx context.Context, current, keyspace Keyspace) error {
+	steps := ReplicationSteps(current.Replication, keyspace.Replication)
+	for i, replication := range steps {
+		step := keyspace
+		step.Replication = replication
+		if i < len(steps)-1 {
+			step.DurableWrites = current.DurableWrites
+		}
+		if err := c.Session.Query(alterKeyspaceStatement(step)).Exec(); err != nil {
+			return fmt.Errorf("failed to apply step %d of %d of the replication change of %s: %w", i+1, len(steps), keyspace.Name, err)
+		}
+		if err := c.Session.AwaitSchemaAgreement(ctx); err != nil {
+			return err
+		}
+		if err := c.awaitTabletMigration(ctx, keyspace.Name); err != nil {
+			return fmt.Errorf("failed to wait for step %d of %d of the replication change of %s: %w", i+1, len(steps), keyspace.Name, err)
+		}
+	}
+	return nil
+}
+
+// awaitTabletMigration polls system.tablets until no tablet of keyspace is
+// in transition or ctx is done.
+func (c *Cluster) awaitTabletMigration(ctx context.Context, keyspace string) error {
+	ticker := time.NewTicker(tabletMigrationPollInterval)
+	defer ticker.Stop()
+	for {
+		migrating, err := c.countMigratingTablets(keyspace)
+		if err != nil {
+			return err
+		}
+		if migrating == 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%d tablets are still migrating: %w", migrating, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// countMigratingTablets returns the number of tablets of keyspace that have
+// a transition stage in system.tablets.
+func (c *Cluster) countMigratingTablets(keyspace string) (int, error) {
+	query := newStatement("SELECT stage FROM").
+		qualifiedIdentifier("system", "tablets").
+		keyword("WHERE keyspace_name = ? ALLOW FILTERING").
+		String()
+	iter := c.Session.Query(query, keyspace).Iter()
+	migrating := 0
+	var stage string
+	for iter.Scan(&stage) {
+		if stage != "" {
+			migrating++
+		}
+		stage = ""
+	}
+	if err := iter.Close(); err != nil {
+		return 0, fmt.Errorf("failed to read the tablets from system.tablets: %w", err)
+	}
+	return migrating, nil
+}
@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package scylladb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func nts(dataCenters map[string]int) Replication {
+	return Replication{Class: NetworkTopologyStrategy, DataCenters: dataCenters}
+}
+
+func TestDiffReplication(t *testing.T) {
+	changes := DiffReplication(
+		nts(map[string]int{"dc1": 3, "dc2": 3, "dc3": 1}),
+		nts(map[string]int{"dc1": 3, "dc2": 1, "dc4": 2}),
+	)
+	assert.Equal(t, []ReplicationChange{
+		{DataCenter: "dc2", From: 3, To: 1},
+		{DataCenter: "dc3", From: 1, To: 0},
+		{DataCenter: "dc4", From: 0, To: 2},
+	}, changes)
+	assert.Equal(t, "dc2: 3 -> 1, dc3: 1 -> 0, dc4: 0 -> 2", FormatReplicationChanges(changes))
+
+	assert.Equal(t, []ReplicationChange{{From: 1, To: 3}}, DiffReplication(
+		Replication{Class: SimpleStrategy, ReplicationFactor: 1},
+		Replication{Class: SimpleStrategy, ReplicationFactor: 3},
+	))
+	assert.Nil(t, DiffReplication(nts(map[string]int{"dc1": 3}), nts(map[string]int{"dc1": 3})))
+	assert.Nil(t, DiffReplication(nts(map[string]int{"dc1": 3}), Replication{Class: SimpleStrategy, ReplicationFactor: 3}))
+}
+
+func TestReplicationSteps(t *testing.T) {
+	current := nts(map[string]int{"dc1": 3, "dc2": 1})
+	desired := nts(map[string]int{"dc1": 2, "dc3": 2})
+	assert.Equal(t, []Replication{
+		// Increases first
+		nts(map[string]int{"dc1": 3, "dc2": 1, "dc3": 1}),
+		nts(map[string]int{"dc1": 3, "dc2": 1, "dc3": 2}),
+		// Then decreases
+		nts(map[string]int{"dc1": 2, "dc2": 1, "dc3": 2}),
+		nts(map[string]int{"dc1": 2, "dc3": 2}),
+	}, ReplicationSteps(current, desired))
+	// The current replication is left untouched
+	assert.Equal(t, nts(map[string]int{"dc1": 3, "dc2": 1}), current)
+
+	assert.Equal(t, []Replication{
+		{Class: SimpleStrategy, ReplicationFactor: 2},
+		{Class: SimpleStrategy, ReplicationFactor: 1},
+	}, ReplicationSteps(Replication{Class: SimpleStrategy, ReplicationFactor: 3}, Replication{Class: SimpleStrategy, ReplicationFactor: 1}))
+
+	simple := Replication{Class: SimpleStrategy, ReplicationFactor: 3}
+	assert.Equal(t, []Replication{simple}, ReplicationSteps(nts(map[string]int{"dc1": 1}), simple))
+	assert.Empty(t, ReplicationSteps(current, current))
+}
+
+func TestReplicationStepsRacks(t *testing.T) {
+	racks := func(dataCenters map[string]int, racks map[string][]string) Replication {
+		return Replication{Class: NetworkTopologyStrategy, DataCenters: dataCenters, Racks: racks}
+	}
+
+	current := racks(nil, map[string][]string{"dc1": {"r1", "r2"}})
+	desired := racks(nil, map[string][]string{"dc1": {"r3", "r2"}, "dc2": {"r1"}})
+	changes := DiffReplication(current, desired)
+	assert.Equal(t, []ReplicationChange{
+		{DataCenter: "dc1", From: 2, To: 2, FromRacks: []string{"r1", "r2"}, ToRacks: []string{"r2", "r3"}},
+		{DataCenter: "dc2", From: 0, To: 1, ToRacks: []string{"r1"}},
+	}, changes)
+	assert.Equal(t, "dc1: [r1, r2] -> [r2, r3], dc2: 0 -> [r1]", FormatReplicationChanges(changes))
+	assert.Equal(t, []Replication{
+		// Racks are added before they are removed
+		racks(nil, map[string][]string{"dc1": {"r1", "r2", "r3"}}),
+		racks(nil, map[string][]string{"dc1": {"r2", "r3"}}),
+		racks(nil, map[string][]string{"dc1": {"r2", "r3"}, "dc2": {"r1"}}),
+	}, ReplicationSteps(current, desired))
+
+	// A replication factor turns into a rack list once it has as many replicas
+	assert.Equal(t, []Replication{
+		racks(map[string]int{"dc1": 2}, nil),
+		racks(map[string]int{}, map[string][]string{"dc1": {"r1", "r2"}}),
+	}, ReplicationSteps(
+		racks(map[string]int{"dc1": 1}, nil),
+		racks(nil, map[string][]string{"dc1": {"r1", "r2"}}),
+	))
+
+	// And the other way around
+	assert.Equal(t, []Replication{
+		racks(map[string]int{"dc1": 2}, map[string][]string{}),
+		racks(map[string]int{"dc1": 1}, map[string][]string{}),
+	}, ReplicationSteps(
+		racks(nil, map[string][]string{"dc1": {"r1", "r2"}}),
+		racks(map[string]int{"dc1": 1}, nil),
+	))
+
+	// Removing a data center removes its racks one at a time
+	assert.Equal(t, []Replication{
+		racks(map[string]int{"dc1": 1}, map[string][]string{"dc2": {"r2"}}),
+		racks(map[string]int{"dc1": 1}, map[string][]string{}),
+	}, ReplicationSteps(
+		racks(map[string]int{"dc1": 1}, map[string][]string{"dc2": {"r1", "r2"}}),
+		racks(map[string]int{"dc1": 1}, nil),
+	))
+}
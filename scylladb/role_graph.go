@@ -0,0 +1,130 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package scylladb
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// RoleGraph is the membership graph of the roles of a cluster. An edge goes
+// from a parent role to each role it was granted to.
+type RoleGraph struct {
+	roles   map[string]struct{}
+	parents map[string][]string
+	members map[string][]string
+}
+
+// NewRoleGraph builds the membership graph from the MemberOf of roles.
+func NewRoleGraph(roles []Role) *RoleGraph {
+	g := &RoleGraph{
+		roles:   make(map[string]struct{}, len(roles)),
+		parents: make(map[string][]string),
+		members: make(map[string][]string),
+	}
+	for _, role := range roles {
+		g.roles[role.Role] = struct{}{}
+		for _, parent := range role.MemberOf {
+			g.parents[role.Role] = append(g.parents[role.Role], parent)
+			g.members[parent] = append(g.members[parent], role.Role)
+		}
+	}
+	for _, edges := range []map[string][]string{g.parents, g.members} {
+		for _, names := range edges {
+			sort.Strings(names)
+		}
+	}
+	return g
+}
+
+// GetRoleGraph reads every role of the cluster and returns their membership
+// graph.
+func (c *Cluster) GetRoleGraph() (*RoleGraph, error) {
+	roles, err := c.ListRoles()
+	if err != nil {
+		return nil, err
+	}
+	return NewRoleGraph(roles), nil
+}
+
+// HasRole reports whether role is part of the graph.
+func (g *RoleGraph) HasRole(role string) bool {
+	_, ok := g.roles[role]
+	return ok
+}
+
+// DirectMembers returns the roles that were granted role.
+func (g *RoleGraph) DirectMembers(role string) []string {
+	return g.members[role]
+}
+
+// TransitiveMembers returns every role that inherits role, directly or
+// through other roles.
+func (g *RoleGraph) TransitiveMembers(role string) []string {
+	return walk(g.members, role)
+}
+
+// DirectParents returns the roles that were granted to role.
+func (g *RoleGraph) DirectParents(role string) []string {
+	return g.parents[role]
+}
+
+// TransitiveParents returns every role that role holds, directly or through
+// other roles.
+func (g *RoleGraph) TransitiveParents(role string) []string {
+	return walk(g.parents, role)
+}
+
+// roleGraphJSON is the JSON representation of a single role of the graph.
+type roleGraphJSON struct {
+	MemberOf []string `json:"member_of"`
+	Members  []string `json:"members"`
+}
+
+// MarshalJSON exports the whole graph as an object keyed by role name, with
+// the direct parents and members of each role.
+func (g *RoleGraph) MarshalJSON() ([]byte, error) {
+	out := make(map[string]roleGraphJSON, len(g.roles))
+	for role := range g.roles {
+		entry := roleGraphJSON{
+			MemberOf: g.parents[role],
+			Members:  g.members[role],
+		}
+		if entry.MemberOf == nil {
+			entry.MemberOf = []string{}
+		}
+		if entry.Members == nil {
+			entry.Members = []string{}
+		}
+		out[role] = entry
+	}
+	b, err := json.Marshal(out)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the role graph: %w", err)
+	}
+	return b, nil
+}
+
+// walk returns every node reachable from start along edges, excluding start
+// itself, sorted by name. Cycles are tolerated.
+func walk(edges map[string][]string, start string) []string {
+	visited := map[string]struct{}{start: {}}
+	queue := []string{start}
+	var reached []string
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, next := range edges[current] {
+			if _, ok := visited[next]; ok {
+				continue
+			}
+			visited[next] = struct{}{}
+			reached = append(reached, next)
+			queue = append(queue, next)
+		}
+	}
+	sort.Strings(reached)
+	return reached
+}
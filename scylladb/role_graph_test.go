@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package scylladb
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testRoles describes the graph
+//
+//	reader -> writer -> admin
+//	reader -> auditor
+//	writer -> app
+func testRoles() []Role {
+	return []Role{
+		{Role: "reader"},
+		{Role: "writer", MemberOf: []string{"reader"}},
+		{Role: "admin", MemberOf: []string{"writer"}},
+		{Role: "auditor", MemberOf: []string{"reader"}},
+		{Role: "app", MemberOf: []string{"writer"}},
+		{Role: "loner"},
+	}
+}
+
+func TestRoleGraphMembers(t *testing.T) {
+	g := NewRoleGraph(testRoles())
+
+	assert.Equal(t, []string{"auditor", "writer"}, g.DirectMembers("reader"))
+	assert.Equal(t, []string{"admin", "app", "auditor", "writer"}, g.TransitiveMembers("reader"))
+	assert.Equal(t, []string{"admin", "app"}, g.TransitiveMembers("writer"))
+	assert.Empty(t, g.DirectMembers("loner"))
+	assert.Empty(t, g.TransitiveMembers("loner"))
+}
+
+func TestRoleGraphParents(t *testing.T) {
+	g := NewRoleGraph(testRoles())
+
+	assert.Equal(t, []string{"writer"}, g.DirectParents("admin"))
+	assert.Equal(t, []string{"reader", "writer"}, g.TransitiveParents("admin"))
+	assert.Empty(t, g.TransitiveParents("reader"))
+	assert.True(t, g.HasRole("loner"))
+	assert.False(t, g.HasRole("missing"))
+}
+
+func TestRoleGraphCycle(t *testing.T) {
+	g := NewRoleGraph([]Role{
+		{Role: "a", MemberOf: []string{"b"}},
+		{Role: "b", MemberOf: []string{"a"}},
+	})
+
+	assert.Equal(t, []string{"b"}, g.TransitiveMembers("a"))
+	assert.Equal(t, []string{"b"}, g.TransitiveParents("a"))
+}
+
+func TestRoleGraphJSON(t *testing.T) {
+	g := NewRoleGraph([]Role{
+		{Role: "reader"},
+		{Role: "app", MemberOf: []string{"reader"}},
+	})
+
+	b, err := json.Marshal(g)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"app": {"member_of": ["reader"], "members": []},
+		"reader": {"member_of": [], "members": ["app"]}
+	}`, string(b))
+}
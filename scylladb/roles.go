@@ -4,55 +4,418 @@
 package scylladb
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
-	"unicode"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
 )
 
 type Role struct {
 	Role        string
 	CanLogin    bool
 	IsSuperuser bool
-	MemberOf    []string
+	// MemberOf lists the roles granted to the role. When creating or
+	// altering a role, a nil slice leaves the memberships untouched while an
+	// empty slice revokes all of them.
+	MemberOf []string
+	// Password and HashedPassword are only used when creating or altering a
+	// role and are never populated by GetRole. At most one of them may be set.
+	Password       string
+	HashedPassword string
+	// SaltedHash is the password hash stored by the cluster. It is populated
+	// by GetRole and ignored when creating or altering a role.
+	SaltedHash string
+	// Options are the custom OPTIONS of the role. A nil map leaves the options
+	// untouched while an empty map clears them. GetRole returns nil when the
+	// role has no options or the role manager does not report them.
+	Options map[string]string
 }
 
 func (c *Cluster) GetRole(roleName string) (Role, error) {
 	var role Role
-	query := fmt.Sprintf("SELECT role, can_login, is_superuser, member_of FROM %s.roles WHERE role = ?", c.SystemAuthKeyspaceName)
+	query := newStatement("SELECT role, can_login, is_superuser, member_of, salted_hash FROM").
+		qualifiedIdentifier(c.SystemAuthKeyspaceName, "roles").
+		keyword("WHERE role = ?").
+		String()
 	if err := c.Session.Query(query, roleName).Scan(
 		&role.Role,
 		&role.CanLogin,
 		&role.IsSuperuser,
 		&role.MemberOf,
+		&role.SaltedHash,
 	); err != nil {
-		return Role{}, err
+		return Role{}, notFound(err, ErrRoleNotFound, roleName)
+	}
+	if c.isAuthV2() {
+		memberOf, err := c.getRoleMemberOf(roleName)
+		if err != nil {
+			return Role{}, err
+		}
+		role.MemberOf = memberOf
+	}
+	sort.Strings(role.MemberOf)
+	if c.supportsRoleOptions() {
+		options, err := c.getRoleOptions(roleName)
+		if err != nil {
+			return Role{}, err
+		}
+		role.Options = options
 	}
 	return role, nil
 }
 
+// ListRoles returns every role in the cluster sorted by name.
+func (c *Cluster) ListRoles() ([]Role, error) {
+	query := newStatement("SELECT role, can_login, is_superuser, member_of, salted_hash FROM").
+		qualifiedIdentifier(c.SystemAuthKeyspaceName, "roles").
+		String()
+	iter := c.Session.Query(query).Iter()
+	var roles []Role
+	var role Role
+	for iter.Scan(&role.Role, &role.CanLogin, &role.IsSuperuser, &role.MemberOf, &role.SaltedHash) {
+		roles = append(roles, role)
+		role = Role{}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+
+	var memberOf map[string][]string
+	if c.isAuthV2() {
+		var err error
+		memberOf, err = c.listRoleMembers()
+		if err != nil {
+			return nil, err
+		}
+	}
+	var options map[string]map[string]string
+	if c.supportsRoleOptions() {
+		var err error
+		options, err = c.listRoleOptions()
+		if err != nil {
+			return nil, err
+		}
+	}
+	for i := range roles {
+		if c.isAuthV2() {
+			roles[i].MemberOf = memberOf[roles[i].Role]
+		}
+		sort.Strings(roles[i].MemberOf)
+		roles[i].Options = options[roles[i].Role]
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i].Role < roles[j].Role })
+	return roles, nil
+}
+
+// RoleFilter selects roles by their attributes. Nil and empty fields match
+// any role.
+type RoleFilter struct {
+	CanLogin    *bool
+	IsSuperuser *bool
+	// MemberOf matches roles that were directly granted this role.
+	MemberOf  string
+	NameRegex *regexp.Regexp
+}
+
+// Match reports whether role satisfies every criterion of the filter.
+func (f RoleFilter) Match(role Role) bool {
+	if f.CanLogin != nil && *f.CanLogin != role.CanLogin {
+		return false
+	}
+	if f.IsSuperuser != nil && *f.IsSuperuser != role.IsSuperuser {
+		return false
+	}
+	if f.MemberOf != "" && !slices.Contains(role.MemberOf, f.MemberOf) {
+		return false
+	}
+	if f.NameRegex != nil && !f.NameRegex.MatchString(role.Role) {
+		return false
+	}
+	return true
+}
+
 func (c *Cluster) CreateRole(role Role) error {
 	if err := validateRoleName(role.Role); err != nil {
 		return err
 	}
-	query := fmt.Sprintf(`CREATE ROLE '%s' WITH LOGIN = %v AND SUPERUSER = %v`, role.Role, role.CanLogin, role.IsSuperuser)
-	return c.Session.Query(query).Exec()
+	if err := validateRolePassword(role); err != nil {
+		return err
+	}
+	if err := c.Session.Query(createRoleStatement(role)).Exec(); err != nil {
+		return err
+	}
+	return c.syncRoleMemberOf(role.Role, nil, role.MemberOf)
 }
 
 func (c *Cluster) UpdateRole(role Role) error {
-	query := fmt.Sprintf(`ALTER ROLE '%s' WITH LOGIN = %v AND SUPERUSER = %v`, role.Role, role.CanLogin, role.IsSuperuser)
-	return c.Session.Query(query).Exec()
+	if err := validateRoleName(role.Role); err != nil {
+		return err
+	}
+	if err := validateRolePassword(role); err != nil {
+		return err
+	}
+	if err := c.Session.Query(alterRoleStatement(role)).Exec(); err != nil {
+		return err
+	}
+	if role.MemberOf == nil {
+		return nil
+	}
+	current, err := c.GetRole(role.Role)
+	if err != nil {
+		return err
+	}
+	return c.syncRoleMemberOf(role.Role, current.MemberOf, role.MemberOf)
 }
 
 func (c *Cluster) DeleteRole(role Role) error {
-	query := fmt.Sprintf(`DROP ROLE '%s'`, role.Role)
+	if err := validateRoleName(role.Role); err != nil {
+		return err
+	}
+	query := newStatement("DROP ROLE").literal(role.Role).String()
 	return c.Session.Query(query).Exec()
 }
 
-func validateRoleName(name string) error {
-	// Only allow alphanumeric and underscore
-	for _, r := range name {
-		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
-			return fmt.Errorf("invalid character in role name: %c", r)
+// GrantRole makes grantee a member of role.
+func (c *Cluster) GrantRole(role, grantee string) error {
+	query := newStatement("GRANT").literal(role).keyword("TO").literal(grantee).String()
+	return c.Session.Query(query).Exec()
+}
+
+// RevokeRole removes grantee from the members of role.
+func (c *Cluster) RevokeRole(role, grantee string) error {
+	query := newStatement("REVOKE").literal(role).keyword("FROM").literal(grantee).String()
+	return c.Session.Query(query).Exec()
+}
+
+// HasRoleGrant reports whether grantee was directly granted role. It returns
+// ErrRoleNotFound when grantee does not exist.
+func (c *Cluster) HasRoleGrant(role, grantee string) (bool, error) {
+	granteeRole, err := c.GetRole(grantee)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(granteeRole.MemberOf, role), nil
+}
+
+// createRoleStatement renders the CREATE ROLE statement for role. Role names
+// are written as string literals so that they keep their exact spelling.
+func createRoleStatement(role Role) string {
+	return newStatement("CREATE ROLE").literal(role.Role).with(roleOptions(role)...).String()
+}
+
+// alterRoleStatement renders the ALTER ROLE statement for role.
+func alterRoleStatement(role Role) string {
+	return newStatement("ALTER ROLE").literal(role.Role).with(roleOptions(role)...).String()
+}
+
+// PasswordFingerprint returns a SHA-256 fingerprint of a salted password
+// hash, or an empty string when there is no hash. The fingerprint allows
+// detecting password changes without keeping the hash itself.
+func PasswordFingerprint(saltedHash string) string {
+	if saltedHash == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(saltedHash))
+	return hex.EncodeToString(sum[:])
+}
+
+// roleOptions returns the WITH options of a CREATE or ALTER ROLE statement.
+// The password is only included when the role has one to set.
+func roleOptions(role Role) []option {
+	var options []option
+	switch {
+	case role.Password != "":
+		options = append(options, stringOption("PASSWORD", role.Password))
+	case role.HashedPassword != "":
+		options = append(options, stringOption("HASHED PASSWORD", role.HashedPassword))
+	}
+	options = append(options,
+		boolOption("LOGIN", role.CanLogin),
+		boolOption("SUPERUSER", role.IsSuperuser),
+	)
+	if role.Options != nil {
+		options = append(options, mapOption("OPTIONS", role.Options))
+	}
+	return options
+}
+
+func validateRolePassword(role Role) error {
+	if role.Password != "" && role.HashedPassword != "" {
+		return errors.New("only one of password and hashed password can be set")
+	}
+	return nil
+}
+
+// isAuthV2 reports whether the cluster keeps auth data in the raft-based
+// system keyspace rather than the legacy system_auth keyspace.
+func (c *Cluster) isAuthV2() bool {
+	return c.SystemAuthKeyspaceName == "system"
+}
+
+// standardRoleManagers are the names of the built-in role manager, which
+// does not support custom options.
+var standardRoleManagers = []string{
+	"org.apache.cassandra.auth.CassandraRoleManager",
+	"CassandraRoleManager",
+	"standard_role_manager",
+}
+
+// supportsRoleOptions reports whether the role manager of the cluster
+// supports custom OPTIONS. The role manager is read from system.config once
+// per session. When it cannot be read, the options are assumed to be
+// supported so that they are still read back.
+func (c *Cluster) supportsRoleOptions() bool {
+	c.roleManagerOnce.Do(func() {
+		query := newStatement("SELECT value FROM").
+			qualifiedIdentifier("system", "config").
+			keyword("WHERE name = 'role_manager'").
+			String()
+		var value string
+		if err := c.Session.Query(query).Scan(&value); err != nil {
+			c.roleOptions = true
+			return
+		}
+		c.roleOptions = isCustomRoleManager(value)
+	})
+	return c.roleOptions
+}
+
+// isCustomRoleManager reports whether the role_manager setting names a role
+// manager other than the built-in one. system.config reports the setting as
+// a JSON string.
+func isCustomRoleManager(value string) bool {
+	value = strings.Trim(strings.TrimSpace(value), `"`)
+	return value != "" && !slices.Contains(standardRoleManagers, value)
+}
+
+// getRoleMemberOf reads the roles granted to roleName from role_members,
+// which is where auth-v2 keeps memberships.
+func (c *Cluster) getRoleMemberOf(roleName string) ([]string, error) {
+	query := newStatement("SELECT role FROM").
+		qualifiedIdentifier(c.SystemAuthKeyspaceName, "role_members").
+		keyword("WHERE member = ? ALLOW FILTERING").
+		String()
+	iter := c.Session.Query(query, roleName).Iter()
+	var memberOf []string
+	var parent string
+	for iter.Scan(&parent) {
+		memberOf = append(memberOf, parent)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return memberOf, nil
+}
+
+// getRoleOptions reads the custom OPTIONS of roleName from LIST ROLES, which
+// is the only place the cluster exposes them. The built-in role manager does
+// not support custom options and always reports an empty map.
+func (c *Cluster) getRoleOptions(roleName string) (map[string]string, error) {
+	query := newStatement("LIST ROLES OF").literal(roleName).keyword("NORECURSIVE").String()
+	rows, err := c.Session.Query(query).Iter().SliceMap()
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		if row["role"] != roleName {
+			continue
+		}
+		options, _ := row["options"].(map[string]string)
+		if len(options) == 0 {
+			return nil, nil
 		}
+		return options, nil
+	}
+	return nil, nil
+}
+
+// listRoleMembers reads every membership from role_members and returns the
+// roles granted to each member.
+func (c *Cluster) listRoleMembers() (map[string][]string, error) {
+	query := newStatement("SELECT role, member FROM").
+		qualifiedIdentifier(c.SystemAuthKeyspaceName, "role_members").
+		String()
+	iter := c.Session.Query(query).Iter()
+	memberOf := make(map[string][]string)
+	var parent, member string
+	for iter.Scan(&parent, &member) {
+		memberOf[member] = append(memberOf[member], parent)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return memberOf, nil
+}
+
+// listRoleOptions reads the custom OPTIONS of every role from LIST ROLES.
+// Roles without options are left out.
+func (c *Cluster) listRoleOptions() (map[string]map[string]string, error) {
+	rows, err := c.Session.Query("LIST ROLES").Iter().SliceMap()
+	if err != nil {
+		return nil, err
+	}
+	options := make(map[string]map[string]string)
+	for _, row := range rows {
+		name, _ := row["role"].(string)
+		if o, _ := row["options"].(map[string]string); len(o) > 0 {
+			options[name] = o
+		}
+	}
+	return options, nil
+}
+
+// syncRoleMemberOf issues the GRANT and REVOKE statements needed to turn the
+// current memberships of roleName into the desired ones.
+func (c *Cluster) syncRoleMemberOf(roleName string, current, desired []string) error {
+	grants, revokes := diffRoleSets(current, desired)
+	for _, parent := range revokes {
+		if err := c.RevokeRole(parent, roleName); err != nil {
+			return fmt.Errorf("failed to revoke %s from %s: %w", parent, roleName, err)
+		}
+	}
+	for _, parent := range grants {
+		if err := c.GrantRole(parent, roleName); err != nil {
+			return fmt.Errorf("failed to grant %s to %s: %w", parent, roleName, err)
+		}
+	}
+	return nil
+}
+
+// diffRoleSets returns the roles in desired but not in current (to grant)
+// and the roles in current but not in desired (to revoke), both sorted.
+func diffRoleSets(current, desired []string) (grants, revokes []string) {
+	currentSet := make(map[string]struct{}, len(current))
+	for _, r := range current {
+		currentSet[r] = struct{}{}
+	}
+	desiredSet := make(map[string]struct{}, len(desired))
+	for _, r := range desired {
+		desiredSet[r] = struct{}{}
+	}
+	for r := range desiredSet {
+		if _, ok := currentSet[r]; !ok {
+			grants = append(grants, r)
+		}
+	}
+	for r := range currentSet {
+		if _, ok := desiredSet[r]; !ok {
+			revokes = append(revokes, r)
+		}
+	}
+	sort.Strings(grants)
+	sort.Strings(revokes)
+	return grants, revokes
+}
+
+// validateRoleName rejects names that Scylla does not accept. Any other
+// character is safe because names are always quoted.
+func validateRoleName(name string) error {
+	if name == "" {
+		return errors.New("role name must not be empty")
 	}
 	return nil
 }
@@ -5,6 +5,7 @@ package scylladb
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/i1snow/terraform-provider-scylladb/internal/testutil"
@@ -14,9 +15,14 @@ import (
 // newTestCluster creates a Cluster connected to a test ScyllaDB container.
 func newTestCluster(t *testing.T) *Cluster {
 	host := testutil.NewTestContainer(t)
+	return newTestClusterForHost(t, host, "cassandra", "cassandra")
+}
+
+// newTestClusterForHost creates a Cluster connected to host as the given user.
+func newTestClusterForHost(t *testing.T, host, username, password string) *Cluster {
 	cluster := NewClusterConfig([]string{host})
 	cluster.SetSystemAuthKeyspace("system")
-	cluster.SetUserPasswordAuth("cassandra", "cassandra")
+	cluster.SetUserPasswordAuth(username, password)
 	if err := cluster.CreateSession(); err != nil {
 		t.Fatalf("failed to create session: %s", err)
 	}
@@ -32,11 +38,14 @@ func TestGetRoleCassandra(t *testing.T) {
 		t.Fatalf("failed to get role: %s", err)
 	}
 
+	assert.NotEmpty(t, role.SaltedHash)
+
 	expectedRole := Role{
 		Role:        "cassandra",
 		CanLogin:    true,
 		IsSuperuser: true,
 		MemberOf:    nil,
+		SaltedHash:  role.SaltedHash,
 	}
 
 	assert.Equal(t, expectedRole, role)
@@ -131,5 +140,318 @@ func TestDeleteRole(t *testing.T) {
 	}
 
 	_, err = cluster.GetRole(inputRole.Role)
-	assert.EqualError(t, err, "not found")
+	assert.ErrorIs(t, err, ErrRoleNotFound)
+	assert.EqualError(t, err, "role not found: testRole")
+}
+
+func TestCreateRoleMemberOf(t *testing.T) {
+	cluster := newTestCluster(t)
+	defer cluster.Session.Close()
+
+	for _, name := range []string{"parentA", "parentB"} {
+		if err := cluster.CreateRole(Role{Role: name}); err != nil {
+			t.Fatalf("failed to create a role: %s", err)
+		}
+	}
+
+	inputRole := Role{
+		Role:     "testRole",
+		MemberOf: []string{"parentB", "parentA"},
+	}
+	err := cluster.CreateRole(inputRole)
+	if err != nil {
+		t.Fatalf("failed to create a role: %s", err)
+	}
+
+	role, err := cluster.GetRole(inputRole.Role)
+	if err != nil {
+		t.Fatalf("failed to get a role for %s: %s", inputRole.Role, err)
+	}
+
+	assert.Equal(t, []string{"parentA", "parentB"}, role.MemberOf)
+}
+
+func TestUpdateRoleMemberOf(t *testing.T) {
+	cluster := newTestCluster(t)
+	defer cluster.Session.Close()
+
+	for _, name := range []string{"parentA", "parentB", "parentC"} {
+		if err := cluster.CreateRole(Role{Role: name}); err != nil {
+			t.Fatalf("failed to create a role: %s", err)
+		}
+	}
+
+	err := cluster.CreateRole(Role{
+		Role:     "testRole",
+		MemberOf: []string{"parentA", "parentB"},
+	})
+	if err != nil {
+		t.Fatalf("failed to create a role: %s", err)
+	}
+
+	err = cluster.UpdateRole(Role{
+		Role:     "testRole",
+		MemberOf: []string{"parentB", "parentC"},
+	})
+	if err != nil {
+		t.Fatalf("failed to update a role: %s", err)
+	}
+
+	role, err := cluster.GetRole("testRole")
+	if err != nil {
+		t.Fatalf("failed to get a role for testRole: %s", err)
+	}
+
+	assert.Equal(t, []string{"parentB", "parentC"}, role.MemberOf)
+}
+
+func TestRolePassword(t *testing.T) {
+	host := testutil.NewTestContainer(t)
+	cluster := newTestClusterForHost(t, host, "cassandra", "cassandra")
+	defer cluster.Session.Close()
+
+	err := cluster.CreateRole(Role{
+		Role:     "loginRole",
+		CanLogin: true,
+		Password: "it's a secret",
+	})
+	if err != nil {
+		t.Fatalf("failed to create a role: %s", err)
+	}
+
+	role, err := cluster.GetRole("loginRole")
+	if err != nil {
+		t.Fatalf("failed to get a role for loginRole: %s", err)
+	}
+	assert.Empty(t, role.Password)
+
+	loginCluster := newTestClusterForHost(t, host, "loginRole", "it's a secret")
+	loginCluster.Session.Close()
+
+	err = cluster.UpdateRole(Role{
+		Role:     "loginRole",
+		CanLogin: true,
+		Password: "rotated",
+	})
+	if err != nil {
+		t.Fatalf("failed to update a role: %s", err)
+	}
+
+	loginCluster = newTestClusterForHost(t, host, "loginRole", "rotated")
+	loginCluster.Session.Close()
+}
+
+func TestRoleHashedPassword(t *testing.T) {
+	cluster := newTestCluster(t)
+	defer cluster.Session.Close()
+
+	cassandra, err := cluster.GetRole("cassandra")
+	if err != nil {
+		t.Fatalf("failed to get role: %s", err)
+	}
+
+	// Reuse the hash of the default superuser as a known-good hash
+	err = cluster.CreateRole(Role{
+		Role:           "hashedRole",
+		CanLogin:       true,
+		HashedPassword: cassandra.SaltedHash,
+	})
+	if err != nil {
+		t.Fatalf("failed to create a role: %s", err)
+	}
+
+	role, err := cluster.GetRole("hashedRole")
+	if err != nil {
+		t.Fatalf("failed to get a role for hashedRole: %s", err)
+	}
+	assert.Equal(t, cassandra.SaltedHash, role.SaltedHash)
+	assert.Equal(t, PasswordFingerprint(cassandra.SaltedHash), PasswordFingerprint(role.SaltedHash))
+
+	err = cluster.UpdateRole(Role{
+		Role:           "hashedRole",
+		Password:       "secret",
+		HashedPassword: cassandra.SaltedHash,
+	})
+	assert.Error(t, err)
+}
+
+func TestPasswordFingerprint(t *testing.T) {
+	assert.Empty(t, PasswordFingerprint(""))
+	assert.Equal(t,
+		"2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b",
+		PasswordFingerprint("secret"),
+	)
+	assert.NotEqual(t, PasswordFingerprint("$6$a"), PasswordFingerprint("$6$b"))
+}
+
+func TestRoleSpecialNames(t *testing.T) {
+	cluster := newTestCluster(t)
+	defer cluster.Session.Close()
+
+	names := []string{"app-reader", `it's "quoted"`, "ユーザー", "x' WITH SUPERUSER = true; --"}
+	for _, name := range names {
+		if err := cluster.CreateRole(Role{Role: name}); err != nil {
+			t.Fatalf("failed to create role %q: %s", name, err)
+		}
+		role, err := cluster.GetRole(name)
+		if err != nil {
+			t.Fatalf("failed to get role %q: %s", name, err)
+		}
+		assert.Equal(t, name, role.Role)
+		assert.False(t, role.IsSuperuser)
+	}
+
+	if err := cluster.GrantRole(names[0], names[1]); err != nil {
+		t.Fatalf("failed to grant a role: %s", err)
+	}
+	role, err := cluster.GetRole(names[1])
+	if err != nil {
+		t.Fatalf("failed to get role %q: %s", names[1], err)
+	}
+	assert.Equal(t, []string{names[0]}, role.MemberOf)
+
+	for _, name := range names {
+		if err := cluster.DeleteRole(Role{Role: name}); err != nil {
+			t.Fatalf("failed to delete role %q: %s", name, err)
+		}
+	}
+}
+
+func TestUpdateRoleKeepsMemberOf(t *testing.T) {
+	cluster := newTestCluster(t)
+	defer cluster.Session.Close()
+
+	if err := cluster.CreateRole(Role{Role: "parentA"}); err != nil {
+		t.Fatalf("failed to create a role: %s", err)
+	}
+	if err := cluster.CreateRole(Role{Role: "testRole", MemberOf: []string{"parentA"}}); err != nil {
+		t.Fatalf("failed to create a role: %s", err)
+	}
+
+	// A nil MemberOf leaves the memberships untouched
+	if err := cluster.UpdateRole(Role{Role: "testRole", CanLogin: true}); err != nil {
+		t.Fatalf("failed to update a role: %s", err)
+	}
+	role, err := cluster.GetRole("testRole")
+	if err != nil {
+		t.Fatalf("failed to get a role for testRole: %s", err)
+	}
+	assert.Equal(t, []string{"parentA"}, role.MemberOf)
+
+	// An empty MemberOf revokes every membership
+	if err := cluster.UpdateRole(Role{Role: "testRole", CanLogin: true, MemberOf: []string{}}); err != nil {
+		t.Fatalf("failed to update a role: %s", err)
+	}
+	role, err = cluster.GetRole("testRole")
+	if err != nil {
+		t.Fatalf("failed to get a role for testRole: %s", err)
+	}
+	assert.Empty(t, role.MemberOf)
+}
+
+func TestHasRoleGrant(t *testing.T) {
+	cluster := newTestCluster(t)
+	defer cluster.Session.Close()
+
+	if err := cluster.CreateRole(Role{Role: "parentA"}); err != nil {
+		t.Fatalf("failed to create a role: %s", err)
+	}
+	if err := cluster.CreateRole(Role{Role: "testRole"}); err != nil {
+		t.Fatalf("failed to create a role: %s", err)
+	}
+
+	granted, err := cluster.HasRoleGrant("parentA", "testRole")
+	assert.NoError(t, err)
+	assert.False(t, granted)
+
+	if err := cluster.GrantRole("parentA", "testRole"); err != nil {
+		t.Fatalf("failed to grant a role: %s", err)
+	}
+	granted, err = cluster.HasRoleGrant("parentA", "testRole")
+	assert.NoError(t, err)
+	assert.True(t, granted)
+
+	_, err = cluster.HasRoleGrant("parentA", "missingRole")
+	assert.ErrorIs(t, err, ErrRoleNotFound)
+}
+
+func TestDiffRoleSets(t *testing.T) {
+	grants, revokes := diffRoleSets(
+		[]string{"a", "b", "c"},
+		[]string{"c", "d", "b", "d"},
+	)
+	assert.Equal(t, []string{"d"}, grants)
+	assert.Equal(t, []string{"a"}, revokes)
+
+	grants, revokes = diffRoleSets(nil, nil)
+	assert.Empty(t, grants)
+	assert.Empty(t, revokes)
+}
+
+func TestIsCustomRoleManager(t *testing.T) {
+	assert.False(t, isCustomRoleManager(`"org.apache.cassandra.auth.CassandraRoleManager"`))
+	assert.False(t, isCustomRoleManager("CassandraRoleManager"))
+	assert.False(t, isCustomRoleManager(""))
+	assert.True(t, isCustomRoleManager(`"com.scylladb.auth.LDAPRoleManager"`))
+}
+
+func TestGetRoleNotFound(t *testing.T) {
+	cluster := newTestCluster(t)
+	defer cluster.Session.Close()
+
+	_, err := cluster.GetRole("missingRole")
+	assert.ErrorIs(t, err, ErrRoleNotFound)
+}
+
+func TestListRoles(t *testing.T) {
+	cluster := newTestCluster(t)
+	defer cluster.Session.Close()
+
+	if err := cluster.CreateRole(Role{Role: "reader"}); err != nil {
+		t.Fatalf("failed to create a role: %s", err)
+	}
+	if err := cluster.CreateRole(Role{Role: "app", CanLogin: true, MemberOf: []string{"reader"}}); err != nil {
+		t.Fatalf("failed to create a role: %s", err)
+	}
+
+	roles, err := cluster.ListRoles()
+	if err != nil {
+		t.Fatalf("failed to list roles: %s", err)
+	}
+
+	var names []string
+	for _, role := range roles {
+		names = append(names, role.Role)
+	}
+	assert.Equal(t, []string{"app", "cassandra", "reader"}, names)
+	assert.Equal(t, []string{"reader"}, roles[0].MemberOf)
+	assert.True(t, roles[0].CanLogin)
+	assert.True(t, roles[1].IsSuperuser)
+}
+
+func TestRoleFilterMatch(t *testing.T) {
+	yes, no := true, false
+	role := Role{Role: "app-reader", CanLogin: true, MemberOf: []string{"reader"}}
+
+	tests := []struct {
+		name     string
+		filter   RoleFilter
+		expected bool
+	}{
+		{name: "empty", filter: RoleFilter{}, expected: true},
+		{name: "can login", filter: RoleFilter{CanLogin: &yes}, expected: true},
+		{name: "cannot login", filter: RoleFilter{CanLogin: &no}, expected: false},
+		{name: "superuser", filter: RoleFilter{IsSuperuser: &yes}, expected: false},
+		{name: "not superuser", filter: RoleFilter{IsSuperuser: &no}, expected: true},
+		{name: "member of", filter: RoleFilter{MemberOf: "reader"}, expected: true},
+		{name: "not member of", filter: RoleFilter{MemberOf: "writer"}, expected: false},
+		{name: "regex", filter: RoleFilter{NameRegex: regexp.MustCompile("^app-")}, expected: true},
+		{name: "regex mismatch", filter: RoleFilter{NameRegex: regexp.MustCompile("^db-")}, expected: false},
+		{name: "combined", filter: RoleFilter{CanLogin: &yes, MemberOf: "reader", NameRegex: regexp.MustCompile("reader$")}, expected: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.filter.Match(role))
+		})
+	}
 }
@@ -4,6 +4,8 @@
 package scylladb
 
 import (
+	"sync"
+
 	gocql "github.com/apache/cassandra-gocql-driver/v2"
 )
 
@@ -11,6 +13,12 @@ type Cluster struct {
 	Cluster                *gocql.ClusterConfig
 	SystemAuthKeyspaceName string
 	Session                *gocql.Session
+	// Username is the role the session authenticates as, if any.
+	Username string
+
+	// roleOptions caches whether the role manager supports custom options.
+	roleManagerOnce sync.Once
+	roleOptions     bool
 }
 
 func NewClusterConfig(hosts []string) Cluster {
@@ -36,6 +44,7 @@ func (c *Cluster) SetUserPasswordAuth(username, password string) {
 		Username: username,
 		Password: password,
 	}
+	c.Username = username
 }
 
 func (c *Cluster) SetSystemAuthKeyspace(name string) {
@@ -0,0 +1,531 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package scylladb
+
+import (
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// TableOptions are the options of a table. When creating or altering a
+// table, nil fields are left to the cluster. GetTable populates every field.
+type TableOptions struct {
+	Comment                 *string
+	DefaultTimeToLive       *int
+	GCGraceSeconds          *int
+	BloomFilterFPChance     *float64
+	SpeculativeRetry        *string
+	PaxosGraceSeconds       *int
+	MemtableFlushPeriodInMs *int
+	Compaction              *Compaction
+	Compression             *Compression
+	Caching                 *Caching
+	CDC                     *CDC
+	TombstoneGC             *TombstoneGC
+	PerPartitionRateLimit   *PerPartitionRateLimit
+}
+
+// Compaction strategies tables can be created with.
+const (
+	SizeTieredCompactionStrategy  = "SizeTieredCompactionStrategy"
+	LeveledCompactionStrategy     = "LeveledCompactionStrategy"
+	TimeWindowCompactionStrategy  = "TimeWindowCompactionStrategy"
+	IncrementalCompactionStrategy = "IncrementalCompactionStrategy"
+)
+
+// CompactionStrategies lists the compaction strategies tables can be created
+// with.
+var CompactionStrategies = []string{
+	SizeTieredCompactionStrategy,
+	LeveledCompactionStrategy,
+	TimeWindowCompactionStrategy,
+	IncrementalCompactionStrategy,
+}
+
+// compactionClassPrefix is the package of the compaction strategies, which
+// may be given in front of their short names.
+const compactionClassPrefix = "org.apache.cassandra.db.compaction."
+
+// optionKind tells how the value of a compaction option is checked.
+type optionKind int
+
+const (
+	intKind optionKind = iota
+	floatKind
+	boolKind
+	enumKind
+)
+
+// compactionOption describes an option of a compaction strategy.
+type compactionOption struct {
+	kind   optionKind
+	values []string
+}
+
+var (
+	tombstoneOptions = map[string]compactionOption{
+		"tombstone_threshold":            {kind: floatKind},
+		"tombstone_compaction_interval":  {kind: intKind},
+		"unchecked_tombstone_compaction": {kind: boolKind},
+		"enabled":                        {kind: boolKind},
+	}
+	sizeTieredOptions = map[string]compactionOption{
+		"min_threshold":    {kind: intKind},
+		"max_threshold":    {kind: intKind},
+		"bucket_low":       {kind: floatKind},
+		"bucket_high":      {kind: floatKind},
+		"min_sstable_size": {kind: intKind},
+	}
+	// compactionOptions are the options each compaction strategy accepts.
+	compactionOptions = map[string]map[string]compactionOption{
+		SizeTieredCompactionStrategy: mergeOptions(tombstoneOptions, sizeTieredOptions),
+		LeveledCompactionStrategy: mergeOptions(tombstoneOptions, map[string]compactionOption{
+			"sstable_size_in_mb": {kind: intKind},
+		}),
+		TimeWindowCompactionStrategy: mergeOptions(tombstoneOptions, map[string]compactionOption{
+			"compaction_window_unit":                  {kind: enumKind, values: []string{"MINUTES", "HOURS", "DAYS"}},
+			"compaction_window_size":                  {kind: intKind},
+			"expired_sstable_check_frequency_seconds": {kind: intKind},
+			"timestamp_resolution":                    {kind: enumKind, values: []string{"MICROSECONDS", "MILLISECONDS", "SECONDS", "MINUTES", "HOURS", "DAYS"}},
+			"min_threshold":                           {kind: intKind},
+			"max_threshold":                           {kind: intKind},
+		}),
+		IncrementalCompactionStrategy: mergeOptions(tombstoneOptions, sizeTieredOptions, map[string]compactionOption{
+			"sstable_size_in_mb":       {kind: intKind},
+			"space_amplification_goal": {kind: floatKind},
+		}),
+	}
+)
+
+func mergeOptions(options ...map[string]compactionOption) map[string]compactionOption {
+	merged := map[string]compactionOption{}
+	for _, o := range options {
+		maps.Copy(merged, o)
+	}
+	return merged
+}
+
+// Compaction is the compaction strategy of a table and its options.
+type Compaction struct {
+	// Class is the short name of the compaction strategy.
+	Class   string
+	Options map[string]string
+}
+
+// NormalizeCompactionClass returns the short name of a compaction strategy.
+func NormalizeCompactionClass(class string) string {
+	return strings.TrimPrefix(strings.TrimSpace(class), compactionClassPrefix)
+}
+
+// Validate checks that the strategy is known and that it accepts each of the
+// options with its value.
+func (c Compaction) Validate() error {
+	class := NormalizeCompactionClass(c.Class)
+	accepted, ok := compactionOptions[class]
+	if !ok {
+		return fmt.Errorf("unsupported compaction strategy %q, expected one of %s", c.Class, strings.Join(CompactionStrategies, ", "))
+	}
+	for _, name := range slices.Sorted(maps.Keys(c.Options)) {
+		option, ok := accepted[name]
+		if !ok {
+			return fmt.Errorf("%s does not accept the option %s, expected one of %s", class, name, strings.Join(slices.Sorted(maps.Keys(accepted)), ", "))
+		}
+		if err := option.validate(c.Options[name]); err != nil {
+			return fmt.Errorf("invalid value for the %s option %s: %w", class, name, err)
+		}
+	}
+	return nil
+}
+
+func (o compactionOption) validate(value string) error {
+	switch o.kind {
+	case intKind:
+		if n, err := strconv.Atoi(value); err != nil || n < 0 {
+			return fmt.Errorf("%q is not a non-negative integer", value)
+		}
+	case floatKind:
+		if f, err := strconv.ParseFloat(value, 64); err != nil || f < 0 {
+			return fmt.Errorf("%q is not a non-negative number", value)
+		}
+	case boolKind:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("%q is not true or false", value)
+		}
+	case enumKind:
+		if !slices.Contains(o.values, strings.ToUpper(value)) {
+			return fmt.Errorf("%q is not one of %s", value, strings.Join(o.values, ", "))
+		}
+	}
+	return nil
+}
+
+// options renders the compaction as the map of a `compaction = {...}`
+// option.
+func (c Compaction) options() map[string]string {
+	options := maps.Clone(c.Options)
+	if options == nil {
+		options = map[string]string{}
+	}
+	options["class"] = NormalizeCompactionClass(c.Class)
+	return options
+}
+
+// Compression algorithms tables can be created with.
+var CompressionAlgorithms = []string{"LZ4Compressor", "SnappyCompressor", "DeflateCompressor", "ZstdCompressor"}
+
+// compressionClassPrefix is the package of the compression algorithms, which
+// may be given in front of their short names.
+const compressionClassPrefix = "org.apache.cassandra.io.compress."
+
+// Compression is the compression of the SSTables of a table. An empty Class
+// and a nil ChunkLengthInKB are left to the cluster.
+type Compression struct {
+	Disabled        bool
+	Class           string
+	ChunkLengthInKB *int
+}
+
+// NormalizeCompressionClass returns the short name of a compression
+// algorithm.
+func NormalizeCompressionClass(class string) string {
+	return strings.TrimPrefix(strings.TrimSpace(class), compressionClassPrefix)
+}
+
+// Validate checks the algorithm and the chunk length, which must be a power
+// of two.
+func (c Compression) Validate() error {
+	if c.Class != "" && !slices.Contains(CompressionAlgorithms, NormalizeCompressionClass(c.Class)) {
+		return fmt.Errorf("unsupported compression %q, expected one of %s", c.Class, strings.Join(CompressionAlgorithms, ", "))
+	}
+	if n := c.ChunkLengthInKB; n != nil && (*n < 1 || *n&(*n-1) != 0) {
+		return fmt.Errorf("the chunk length %d is not a power of two", *n)
+	}
+	return nil
+}
+
+// options renders the compression as the map of a `compression = {...}`
+// option. An empty compressor disables compression.
+func (c Compression) options() map[string]string {
+	if c.Disabled {
+		return map[string]string{"sstable_compression": ""}
+	}
+	options := map[string]string{}
+	if c.Class != "" {
+		options["sstable_compression"] = NormalizeCompressionClass(c.Class)
+	}
+	if c.ChunkLengthInKB != nil {
+		options["chunk_length_in_kb"] = strconv.Itoa(*c.ChunkLengthInKB)
+	}
+	return options
+}
+
+// parseCompression parses the compression map of system_schema.tables,
+// which older releases write with the sstable_compression and
+// chunk_length_kb keys.
+func parseCompression(options map[string]string) Compression {
+	class := cmp.Or(options["class"], options["sstable_compression"])
+	if class == "" {
+		return Compression{Disabled: true}
+	}
+	compression := Compression{Class: NormalizeCompressionClass(class)}
+	if chunk, err := strconv.Atoi(cmp.Or(options["chunk_length_in_kb"], options["chunk_length_kb"])); err == nil {
+		compression.ChunkLengthInKB = &chunk
+	}
+	return compression
+}
+
+// Caching is the caching of a table. Keys is ALL or NONE and
+// RowsPerPartition is ALL, NONE or a number of rows. Empty values are left
+// to the cluster.
+type Caching struct {
+	Disabled         bool
+	Keys             string
+	RowsPerPartition string
+}
+
+// Validate checks the values of the caching.
+func (c Caching) Validate() error {
+	if c.Keys != "" && c.Keys != "ALL" && c.Keys != "NONE" {
+		return fmt.Errorf("invalid keys caching %q, expected ALL or NONE", c.Keys)
+	}
+	if c.RowsPerPartition != "" && c.RowsPerPartition != "ALL" && c.RowsPerPartition != "NONE" {
+		if n, err := strconv.Atoi(c.RowsPerPartition); err != nil || n < 1 {
+			return fmt.Errorf("invalid rows_per_partition caching %q, expected ALL, NONE or a positive number", c.RowsPerPartition)
+		}
+	}
+	return nil
+}
+
+// options renders the caching as the map of a `caching = {...}` option.
+func (c Caching) options() map[string]string {
+	if c.Disabled {
+		return map[string]string{"enabled": "false"}
+	}
+	options := map[string]string{}
+	if c.Keys != "" {
+		options["keys"] = c.Keys
+	}
+	if c.RowsPerPartition != "" {
+		options["rows_per_partition"] = c.RowsPerPartition
+	}
+	return options
+}
+
+// parseCaching parses the caching map of system_schema.tables. Caching is
+// reported as disabled only when explicitly turned off.
+func parseCaching(options map[string]string) Caching {
+	return Caching{
+		Disabled:         options["enabled"] == "false",
+		Keys:             options["keys"],
+		RowsPerPartition: options["rows_per_partition"],
+	}
+}
+
+// NormalizeSpeculativeRetry returns the canonical form of a speculative
+// retry policy, so that `99percentile` and `99.0PERCENTILE` compare equal.
+// The policies are NONE, ALWAYS, a percentile such as 99PERCENTILE and a
+// latency in milliseconds such as 50ms.
+func NormalizeSpeculativeRetry(policy string) (string, error) {
+	upper := strings.ToUpper(strings.TrimSpace(policy))
+	switch {
+	case upper == "NONE" || upper == "ALWAYS":
+		return upper, nil
+	case strings.HasSuffix(upper, "PERCENTILE"):
+		value, err := strconv.ParseFloat(strings.TrimSuffix(upper, "PERCENTILE"), 64)
+		if err != nil || value <= 0 || value >= 100 {
+			return "", fmt.Errorf("invalid speculative retry percentile %q", policy)
+		}
+		return strconv.FormatFloat(value, 'f', -1, 64) + "PERCENTILE", nil
+	case strings.HasSuffix(upper, "MS"):
+		value, err := strconv.ParseFloat(strings.TrimSuffix(upper, "MS"), 64)
+		if err != nil || value < 0 {
+			return "", fmt.Errorf("invalid speculative retry latency %q", policy)
+		}
+		return strconv.FormatFloat(value, 'f', -1, 64) + "ms", nil
+	}
+	return "", fmt.Errorf("invalid speculative retry %q, expected NONE, ALWAYS, <n>PERCENTILE or <n>ms", policy)
+}
+
+// EqualSpeculativeRetry reports whether two speculative retry policies are
+// the same.
+func EqualSpeculativeRetry(a, b string) bool {
+	na, errA := NormalizeSpeculativeRetry(a)
+	nb, errB := NormalizeSpeculativeRetry(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return na == nb
+}
+
+// Validate checks the options that are set.
+func (o TableOptions) Validate() error {
+	nonNegative := map[string]*int{
+		"default_time_to_live":        o.DefaultTimeToLive,
+		"gc_grace_seconds":            o.GCGraceSeconds,
+		"paxos_grace_seconds":         o.PaxosGraceSeconds,
+		"memtable_flush_period_in_ms": o.MemtableFlushPeriodInMs,
+	}
+	for _, name := range slices.Sorted(maps.Keys(nonNegative)) {
+		if value := nonNegative[name]; value != nil && *value < 0 {
+			return fmt.Errorf("%s cannot be negative", name)
+		}
+	}
+	if o.BloomFilterFPChance != nil && (*o.BloomFilterFPChance <= 0 || *o.BloomFilterFPChance > 1) {
+		return errors.New("bloom_filter_fp_chance must be greater than 0 and at most 1")
+	}
+	if o.SpeculativeRetry != nil {
+		if _, err := NormalizeSpeculativeRetry(*o.SpeculativeRetry); err != nil {
+			return err
+		}
+	}
+	if o.Compaction != nil {
+		if err := o.Compaction.Validate(); err != nil {
+			return err
+		}
+	}
+	if o.Compression != nil {
+		if err := o.Compression.Validate(); err != nil {
+			return err
+		}
+	}
+	if o.Caching != nil {
+		if err := o.Caching.Validate(); err != nil {
+			return err
+		}
+	}
+	if o.CDC != nil {
+		if err := o.CDC.Validate(); err != nil {
+			return err
+		}
+	}
+	if o.TombstoneGC != nil {
+		if err := o.TombstoneGC.Validate(); err != nil {
+			return err
+		}
+	}
+	if o.PerPartitionRateLimit != nil {
+		if err := o.PerPartitionRateLimit.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IsEmpty reports whether no option is set.
+func (o TableOptions) IsEmpty() bool {
+	return len(o.options()) == 0
+}
+
+// options renders the options that are set as options of a WITH clause.
+func (o TableOptions) options() []option {
+	var options []option
+	intOptions := []struct {
+		name  string
+		value *int
+	}{
+		{"default_time_to_live", o.DefaultTimeToLive},
+		{"gc_grace_seconds", o.GCGraceSeconds},
+		{"paxos_grace_seconds", o.PaxosGraceSeconds},
+		{"memtable_flush_period_in_ms", o.MemtableFlushPeriodInMs},
+	}
+	if o.Comment != nil {
+		options = append(options, stringOption("comment", *o.Comment))
+	}
+	for _, i := range intOptions {
+		if i.value != nil {
+			options = append(options, option{name: i.name, value: strconv.Itoa(*i.value)})
+		}
+	}
+	if o.BloomFilterFPChance != nil {
+		options = append(options, option{name: "bloom_filter_fp_chance", value: strconv.FormatFloat(*o.BloomFilterFPChance, 'f', -1, 64)})
+	}
+	if o.SpeculativeRetry != nil {
+		options = append(options, stringOption("speculative_retry", *o.SpeculativeRetry))
+	}
+	if o.Compaction != nil {
+		options = append(options, mapOption("compaction", o.Compaction.options()))
+	}
+	if o.Compression != nil {
+		options = append(options, mapOption("compression", o.Compression.options()))
+	}
+	if o.Caching != nil {
+		options = append(options, mapOption("caching", o.Caching.options()))
+	}
+	if o.CDC != nil {
+		options = append(options, mapOption("cdc", o.CDC.options()))
+	}
+	if o.TombstoneGC != nil {
+		options = append(options, mapOption("tombstone_gc", o.TombstoneGC.options()))
+	}
+	if o.PerPartitionRateLimit != nil {
+		options = append(options, mapOption("per_partition_rate_limit", o.PerPartitionRateLimit.options()))
+	}
+	return options
+}
+
+// parseTableOptions reads the options of a table from its row of
+// system_schema.tables.
+func parseTableOptions(row map[string]any) (TableOptions, error) {
+	intValue := func(name string) *int {
+		value, _ := row[name].(int)
+		return &value
+	}
+	mapValue := func(name string) map[string]string {
+		value, _ := row[name].(map[string]string)
+		return value
+	}
+	comment, _ := row["comment"].(string)
+	bloomFilterFPChance, _ := row["bloom_filter_fp_chance"].(float64)
+	speculativeRetry, _ := row["speculative_retry"].(string)
+
+	compaction := mapValue("compaction")
+	compactionOptions := maps.Clone(compaction)
+	delete(compactionOptions, "class")
+	compression := parseCompression(mapValue("compression"))
+	caching := parseCaching(mapValue("caching"))
+	// The Scylla options are extensions, absent from the tables that never
+	// set them
+	extensions := map[string]map[string]string{}
+	encoded, _ := row["extensions"].(map[string][]byte)
+	for _, name := range []string{"cdc", "tombstone_gc", "per_partition_rate_limit"} {
+		extension, ok := encoded[name]
+		if !ok {
+			continue
+		}
+		options, err := decodeExtension(extension)
+		if err != nil {
+			return TableOptions{}, fmt.Errorf("invalid %s extension: %w", name, err)
+		}
+		extensions[name] = options
+	}
+	cdc := parseCDC(extensions["cdc"])
+	// The default garbage collection of tombstones depends on the keyspace,
+	// so it is left unknown when the table does not report it
+	var tombstoneGC *TombstoneGC
+	if options, ok := extensions["tombstone_gc"]; ok {
+		gc := parseTombstoneGC(options)
+		tombstoneGC = &gc
+	}
+	perPartitionRateLimit := parsePerPartitionRateLimit(extensions["per_partition_rate_limit"])
+	return TableOptions{
+		Comment:                 &comment,
+		DefaultTimeToLive:       intValue("default_time_to_live"),
+		GCGraceSeconds:          intValue("gc_grace_seconds"),
+		BloomFilterFPChance:     &bloomFilterFPChance,
+		SpeculativeRetry:        &speculativeRetry,
+		PaxosGraceSeconds:       intValue("paxos_grace_seconds"),
+		MemtableFlushPeriodInMs: intValue("memtable_flush_period_in_ms"),
+		Compaction:              &Compaction{Class: NormalizeCompactionClass(compaction["class"]), Options: compactionOptions},
+		Compression:             &compression,
+		Caching:                 &caching,
+		CDC:                     &cdc,
+		TombstoneGC:             tombstoneGC,
+		PerPartitionRateLimit:   &perPartitionRateLimit,
+	}, nil
+}
+
+// decodeExtension decodes an entry of the extensions column of
+// system_schema.tables. Scylla serializes its options as a map of strings: a
+// little-endian count followed by the length-prefixed keys and values.
+func decodeExtension(b []byte) (map[string]string, error) {
+	truncated := errors.New("truncated extension")
+	readString := func() (string, error) {
+		if len(b) < 4 {
+			return "", truncated
+		}
+		n := binary.LittleEndian.Uint32(b)
+		b = b[4:]
+		if uint64(len(b)) < uint64(n) {
+			return "", truncated
+		}
+		s := string(b[:n])
+		b = b[n:]
+		return s, nil
+	}
+
+	if len(b) < 4 {
+		return nil, truncated
+	}
+	count := binary.LittleEndian.Uint32(b)
+	b = b[4:]
+	options := map[string]string{}
+	for range count {
+		key, err := readString()
+		if err != nil {
+			return nil, err
+		}
+		value, err := readString()
+		if err != nil {
+			return nil, err
+		}
+		options[key] = value
+	}
+	return options, nil
+}
@@ -0,0 +1,152 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package scylladb
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompactionValidate(t *testing.T) {
+	valid := []Compaction{
+		{Class: SizeTieredCompactionStrategy},
+		{Class: "org.apache.cassandra.db.compaction.LeveledCompactionStrategy", Options: map[string]string{"sstable_size_in_mb": "160"}},
+		{Class: TimeWindowCompactionStrategy, Options: map[string]string{"compaction_window_unit": "days", "compaction_window_size": "1"}},
+		{Class: IncrementalCompactionStrategy, Options: map[string]string{"space_amplification_goal": "1.5", "min_threshold": "4"}},
+	}
+	for _, c := range valid {
+		assert.NoError(t, c.Validate(), c.Class)
+	}
+
+	assert.EqualError(t, Compaction{Class: "DateTieredCompactionStrategy"}.Validate(),
+		`unsupported compaction strategy "DateTieredCompactionStrategy", expected one of SizeTieredCompactionStrategy, LeveledCompactionStrategy, TimeWindowCompactionStrategy, IncrementalCompactionStrategy`)
+	assert.ErrorContains(t, Compaction{Class: LeveledCompactionStrategy, Options: map[string]string{"min_threshold": "4"}}.Validate(),
+		"LeveledCompactionStrategy does not accept the option min_threshold")
+	assert.EqualError(t, Compaction{Class: TimeWindowCompactionStrategy, Options: map[string]string{"compaction_window_unit": "WEEKS"}}.Validate(),
+		`invalid value for the TimeWindowCompactionStrategy option compaction_window_unit: "WEEKS" is not one of MINUTES, HOURS, DAYS`)
+	assert.Error(t, Compaction{Class: SizeTieredCompactionStrategy, Options: map[string]string{"max_threshold": "-1"}}.Validate())
+}
+
+func TestNormalizeSpeculativeRetry(t *testing.T) {
+	tests := map[string]string{
+		"none":           "NONE",
+		"Always":         "ALWAYS",
+		"99percentile":   "99PERCENTILE",
+		"99.0PERCENTILE": "99PERCENTILE",
+		"50ms":           "50ms",
+		"2.5MS":          "2.5ms",
+	}
+	for policy, expected := range tests {
+		normalized, err := NormalizeSpeculativeRetry(policy)
+		assert.NoError(t, err, policy)
+		assert.Equal(t, expected, normalized, policy)
+	}
+	for _, policy := range []string{"", "100PERCENTILE", "fast", "-1ms"} {
+		_, err := NormalizeSpeculativeRetry(policy)
+		assert.Error(t, err, policy)
+	}
+	assert.True(t, EqualSpeculativeRetry("99percentile", "99.0PERCENTILE"))
+	assert.False(t, EqualSpeculativeRetry("99PERCENTILE", "95PERCENTILE"))
+}
+
+func TestParseTableOptions(t *testing.T) {
+	options, err := parseTableOptions(map[string]any{
+		"comment":                     "events",
+		"default_time_to_live":        0,
+		"gc_grace_seconds":            864000,
+		"bloom_filter_fp_chance":      0.01,
+		"speculative_retry":           "99.0PERCENTILE",
+		"paxos_grace_seconds":         864000,
+		"memtable_flush_period_in_ms": 0,
+		"compaction":                  map[string]string{"class": "org.apache.cassandra.db.compaction.SizeTieredCompactionStrategy", "min_threshold": "4"},
+		"compression":                 map[string]string{"sstable_compression": "org.apache.cassandra.io.compress.LZ4Compressor", "chunk_length_kb": "4"},
+		"caching":                     map[string]string{"keys": "ALL", "rows_per_partition": "NONE"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "events", *options.Comment)
+	assert.Equal(t, 864000, *options.GCGraceSeconds)
+	assert.Equal(t, 0.01, *options.BloomFilterFPChance)
+	assert.Equal(t, &Compaction{Class: SizeTieredCompactionStrategy, Options: map[string]string{"min_threshold": "4"}}, options.Compaction)
+	assert.Equal(t, "LZ4Compressor", options.Compression.Class)
+	assert.Equal(t, 4, *options.Compression.ChunkLengthInKB)
+	assert.Equal(t, &Caching{Keys: "ALL", RowsPerPartition: "NONE"}, options.Caching)
+	defaultTTL := 86400
+	assert.Equal(t, &CDC{Preimage: CDCPreimageOff, Delta: CDCDeltaFull, TTL: &defaultTTL}, options.CDC)
+
+	assert.Equal(t, Compression{Disabled: true}, parseCompression(map[string]string{}))
+	assert.Equal(t, Caching{Disabled: true}, parseCaching(map[string]string{"enabled": "false"}))
+}
+
+func TestTableOptionsStatements(t *testing.T) {
+	ttl := 86400
+	fpChance := 0.1
+	comment := "it's events"
+	table := eventsTable()
+	table.Options = TableOptions{
+		Comment:             &comment,
+		DefaultTimeToLive:   &ttl,
+		BloomFilterFPChance: &fpChance,
+		Compaction:          &Compaction{Class: TimeWindowCompactionStrategy, Options: map[string]string{"compaction_window_size": "1"}},
+		Compression:         &Compression{Disabled: true},
+		Caching:             &Caching{Keys: "ALL"},
+	}
+	assert.NoError(t, table.Validate())
+	assert.Equal(t,
+		`ALTER TABLE "app_ks"."events" WITH comment = 'it''s events' AND default_time_to_live = 86400 AND bloom_filter_fp_chance = 0.1 AND `+
+			`compaction = {'class': 'TimeWindowCompactionStrategy', 'compaction_window_size': '1'} AND compression = {'sstable_compression': ''} AND caching = {'keys': 'ALL'}`,
+		alterTableOptionsStatement(table))
+
+	chunk := 3
+	table.Options = TableOptions{Compression: &Compression{Class: "ZstdCompressor", ChunkLengthInKB: &chunk}}
+	assert.EqualError(t, table.Validate(), "the chunk length 3 is not a power of two")
+	chunk = 0
+	assert.EqualError(t, table.Validate(), "the chunk length 0 is not a power of two")
+	chunk = 16
+	assert.NoError(t, table.Validate())
+	assert.Equal(t, map[string]string{"sstable_compression": "ZstdCompressor", "chunk_length_in_kb": "16"}, table.Options.Compression.options())
+	assert.Equal(t, map[string]string{"sstable_compression": "ZstdCompressor"}, Compression{Class: "ZstdCompressor"}.options())
+	assert.True(t, TableOptions{}.IsEmpty())
+}
+
+// encodeExtension serializes options as Scylla does in the extensions column
+// of system_schema.tables.
+func encodeExtension(options [][2]string) []byte {
+	b := binary.LittleEndian.AppendUint32(nil, uint32(len(options)))
+	for _, option := range options {
+		for _, s := range option {
+			b = binary.LittleEndian.AppendUint32(b, uint32(len(s)))
+			b = append(b, s...)
+		}
+	}
+	return b
+}
+
+func TestDecodeExtension(t *testing.T) {
+	extension := encodeExtension([][2]string{{"enabled", "true"}, {"preimage", "full"}, {"ttl", "3600"}})
+	options, err := decodeExtension(extension)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"enabled": "true", "preimage": "full", "ttl": "3600"}, options)
+
+	_, err = decodeExtension(extension[:len(extension)-1])
+	assert.EqualError(t, err, "truncated extension")
+	_, err = decodeExtension(nil)
+	assert.Error(t, err)
+
+	table, err := parseTableOptions(map[string]any{"extensions": map[string][]byte{
+		"cdc":                      extension,
+		"tombstone_gc":             encodeExtension([][2]string{{"mode", "repair"}, {"propagation_delay_in_seconds", "600"}}),
+		"per_partition_rate_limit": encodeExtension([][2]string{{"max_writes_per_second", "100"}}),
+	}})
+	assert.NoError(t, err)
+	ttl := 3600
+	assert.Equal(t, &CDC{Enabled: true, Preimage: CDCPreimageFull, Delta: CDCDeltaFull, TTL: &ttl}, table.CDC)
+	delay := 600
+	assert.Equal(t, &TombstoneGC{Mode: TombstoneGCRepair, PropagationDelay: &delay}, table.TombstoneGC)
+	assert.Equal(t, 100, *table.PerPartitionRateLimit.MaxWritesPerSecond)
+
+	_, err = parseTableOptions(map[string]any{"extensions": map[string][]byte{"cdc": extension[:3]}})
+	assert.EqualError(t, err, "invalid cdc extension: truncated extension")
+}
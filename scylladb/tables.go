@@ -0,0 +1,420 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package scylladb
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Table is a table, its primary key and its options. Columns holds every
+// column of the table, key columns included, while PartitionKey and
+// ClusteringKey list the names of the key columns in key order.
+type Table struct {
+	Keyspace      string
+	Name          string
+	Columns       []Column
+	PartitionKey  []string
+	ClusteringKey []ClusteringColumn
+	Options       TableOptions
+}
+
+// Column is a column of a table. Type is the CQL type of the column as
+// written in a CREATE TABLE statement.
+type Column struct {
+	Name   string
+	Type   string
+	Static bool
+}
+
+// ClusteringColumn is a column of the clustering key and its clustering
+// order.
+type ClusteringColumn struct {
+	Name       string
+	Descending bool
+}
+
+// Clustering orders of a clustering column.
+const (
+	ClusteringOrderAsc  = "ASC"
+	ClusteringOrderDesc = "DESC"
+)
+
+// Order returns the clustering order of the column, ASC or DESC.
+func (c ClusteringColumn) Order() string {
+	if c.Descending {
+		return ClusteringOrderDesc
+	}
+	return ClusteringOrderAsc
+}
+
+// Column returns the column called name.
+func (t Table) Column(name string) (Column, bool) {
+	i := slices.IndexFunc(t.Columns, func(c Column) bool { return c.Name == name })
+	if i < 0 {
+		return Column{}, false
+	}
+	return t.Columns[i], true
+}
+
+// IsKeyColumn reports whether the column called name is part of the primary
+// key.
+func (t Table) IsKeyColumn(name string) bool {
+	return slices.Contains(t.PartitionKey, name) ||
+		slices.ContainsFunc(t.ClusteringKey, func(c ClusteringColumn) bool { return c.Name == name })
+}
+
+// Validate checks that the table can be created: its columns are unique, its
+// primary key refers to its columns and static columns are only used with a
+// clustering key.
+func (t Table) Validate() error {
+	if err := validateKeyspaceName(t.Keyspace); err != nil {
+		return err
+	}
+	if t.Name == "" {
+		return errors.New("table name cannot be empty")
+	}
+	if len(t.Columns) == 0 {
+		return errors.New("a table requires at least one column")
+	}
+	seen := map[string]bool{}
+	for _, column := range t.Columns {
+		if column.Name == "" {
+			return errors.New("column name cannot be empty")
+		}
+		if seen[column.Name] {
+			return fmt.Errorf("column %s is defined more than once", column.Name)
+		}
+		seen[column.Name] = true
+		if _, err := ParseType(column.Type); err != nil {
+			return fmt.Errorf("column %s: %w", column.Name, err)
+		}
+		if column.Static {
+			if t.IsKeyColumn(column.Name) {
+				return fmt.Errorf("column %s is part of the primary key and cannot be static", column.Name)
+			}
+			if len(t.ClusteringKey) == 0 {
+				return fmt.Errorf("column %s cannot be static in a table without clustering key", column.Name)
+			}
+		}
+	}
+
+	if len(t.PartitionKey) == 0 {
+		return errors.New("the partition key requires at least one column")
+	}
+	keys := map[string]bool{}
+	clustering := make([]string, 0, len(t.ClusteringKey))
+	for _, c := range t.ClusteringKey {
+		clustering = append(clustering, c.Name)
+	}
+	for _, name := range slices.Concat(t.PartitionKey, clustering) {
+		if !seen[name] {
+			return fmt.Errorf("primary key column %s is not a column of the table", name)
+		}
+		if keys[name] {
+			return fmt.Errorf("column %s is used more than once in the primary key", name)
+		}
+		keys[name] = true
+	}
+	return t.Options.Validate()
+}
+
+// DiffColumns returns the columns of desired that current lacks and the
+// names of the columns of current that desired lacks, which is what ALTER
+// TABLE can change. It fails when a column changes its type or whether it
+// is static, which requires recreating the table.
+func DiffColumns(current, desired Table) (add []Column, drop []string, err error) {
+	for _, column := range desired.Columns {
+		existing, ok := current.Column(column.Name)
+		if !ok {
+			add = append(add, column)
+			continue
+		}
+		if !EqualTypes(existing.Type, column.Type) {
+			return nil, nil, fmt.Errorf("the type of column %s cannot change from %s to %s", column.Name, existing.Type, column.Type)
+		}
+		if existing.Static != column.Static {
+			return nil, nil, fmt.Errorf("column %s cannot change whether it is static", column.Name)
+		}
+	}
+	for _, column := range current.Columns {
+		if _, ok := desired.Column(column.Name); !ok {
+			if current.IsKeyColumn(column.Name) {
+				return nil, nil, fmt.Errorf("column %s is part of the primary key and cannot be dropped", column.Name)
+			}
+			drop = append(drop, column.Name)
+		}
+	}
+	return add, drop, nil
+}
+
+// GetTable reads a table, its columns and its options from system_schema.
+// Key columns come first in key order, followed by the other columns sorted
+// by name.
+func (c *Cluster) GetTable(keyspace, name string) (Table, error) {
+	query := newStatement("SELECT * FROM").
+		qualifiedIdentifier("system_schema", "tables").
+		keyword("WHERE keyspace_name = ? AND table_name = ?").
+		String()
+	row := map[string]any{}
+	if err := c.Session.Query(query, keyspace, name).MapScan(row); err != nil {
+		return Table{}, notFound(err, ErrTableNotFound, keyspace+"."+name)
+	}
+
+	options, err := parseTableOptions(row)
+	if err != nil {
+		return Table{}, fmt.Errorf("failed to parse the options of %s.%s: %w", keyspace, name, err)
+	}
+	table := Table{Keyspace: keyspace, Name: name, Options: options}
+	type keyColumn struct {
+		position int
+		name     string
+		order    string
+	}
+	var partition, clustering []keyColumn
+	var others []Column
+	query = newStatement("SELECT column_name, clustering_order, kind, position, type FROM").
+		qualifiedIdentifier("system_schema", "columns").
+		keyword("WHERE keyspace_name = ? AND table_name = ?").
+		String()
+	iter := c.Session.Query(query, keyspace, name).Iter()
+	var column Column
+	var order, kind string
+	var position int
+	for iter.Scan(&column.Name, &order, &kind, &position, &column.Type) {
+		switch kind {
+		case "partition_key":
+			partition = append(partition, keyColumn{position, column.Name, order})
+			table.Columns = append(table.Columns, Column{Name: column.Name, Type: column.Type})
+		case "clustering":
+			clustering = append(clustering, keyColumn{position, column.Name, order})
+			table.Columns = append(table.Columns, Column{Name: column.Name, Type: column.Type})
+		default:
+			others = append(others, Column{Name: column.Name, Type: column.Type, Static: kind == "static"})
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return Table{}, err
+	}
+
+	byPosition := func(a, b keyColumn) int { return cmp.Compare(a.position, b.position) }
+	slices.SortFunc(partition, byPosition)
+	slices.SortFunc(clustering, byPosition)
+	for _, k := range partition {
+		table.PartitionKey = append(table.PartitionKey, k.name)
+	}
+	for _, k := range clustering {
+		table.ClusteringKey = append(table.ClusteringKey, ClusteringColumn{Name: k.name, Descending: strings.EqualFold(k.order, "desc")})
+	}
+	// Key columns first, in key order
+	keyOrder := slices.Concat(table.PartitionKey, clusteringNames(table.ClusteringKey))
+	slices.SortFunc(table.Columns, func(a, b Column) int {
+		return cmp.Compare(slices.Index(keyOrder, a.Name), slices.Index(keyOrder, b.Name))
+	})
+	slices.SortFunc(others, func(a, b Column) int { return cmp.Compare(a.Name, b.Name) })
+	table.Columns = append(table.Columns, others...)
+	return table, nil
+}
+
+func (c *Cluster) CreateTable(table Table) error {
+	if err := table.Validate(); err != nil {
+		return err
+	}
+	stmt, err := createTableStatement(table)
+	if err != nil {
+		return err
+	}
+	if err := c.Session.Query(stmt).Exec(); err != nil {
+		return err
+	}
+	return c.Session.AwaitSchemaAgreement(context.Background())
+}
+
+// AlterTable adds the columns of table that the existing table lacks, drops
+// those it no longer has and sets the options of table that are set. The
+// primary key cannot change.
+func (c *Cluster) AlterTable(table Table) error {
+	if err := table.Validate(); err != nil {
+		return err
+	}
+	current, err := c.GetTable(table.Keyspace, table.Name)
+	if err != nil {
+		return err
+	}
+	if !slices.Equal(current.PartitionKey, table.PartitionKey) || !slices.Equal(current.ClusteringKey, table.ClusteringKey) {
+		return fmt.Errorf("the primary key of %s.%s cannot change", table.Keyspace, table.Name)
+	}
+	add, drop, err := DiffColumns(current, table)
+	if err != nil {
+		return err
+	}
+	statements, err := alterTableColumnsStatements(table, add, drop)
+	if err != nil {
+		return err
+	}
+	if !table.Options.IsEmpty() {
+		statements = append(statements, alterTableOptionsStatement(table))
+	}
+	for _, statement := range statements {
+		if err := c.Session.Query(statement).Exec(); err != nil {
+			return err
+		}
+	}
+	return c.Session.AwaitSchemaAgreement(context.Background())
+}
+
+func (c *Cluster) DropTable(keyspace, name string) error {
+	if err := validateKeyspaceName(keyspace); err != nil {
+		return err
+	}
+	return c.Session.Query(newStatement("DROP TABLE").qualifiedIdentifier(keyspace, name).String()).Exec()
+}
+
+// ListTables returns the names of the tables of a keyspace, sorted by name.
+// Materialized views are not tables and are not listed.
+func (c *Cluster) ListTables(keyspace string) ([]string, error) {
+	return c.listSchemaNames("tables", "table_name", keyspace)
+}
+
+// IsTableEmpty reports whether a table has no rows. It reads at most one row,
+// so it is cheap whatever the size of the table.
+func (c *Cluster) IsTableEmpty(keyspace, table string) (bool, error) {
+	query := newStatement("SELECT * FROM").qualifiedIdentifier(keyspace, table).keyword("LIMIT 1").String()
+	iter := c.Session.Query(query).Iter()
+	found := iter.MapScan(map[string]any{})
+	if err := iter.Close(); err != nil {
+		return false, fmt.Errorf("failed to check whether %s.%s is empty: %w", keyspace, table, err)
+	}
+	return !found, nil
+}
+
+// NonEmptyTable returns the name of the first table of a keyspace that has
+// rows, or an empty string when all of its tables are empty.
+func (c *Cluster) NonEmptyTable(keyspace string) (string, error) {
+	tables, err := c.ListTables(keyspace)
+	if err != nil {
+		return "", err
+	}
+	for _, table := range tables {
+		empty, err := c.IsTableEmpty(keyspace, table)
+		if err != nil {
+			return "", err
+		}
+		if !empty {
+			return table, nil
+		}
+	}
+	return "", nil
+}
+
+func createTableStatement(table Table) (string, error) {
+	definitions := make([]string, 0, len(table.Columns)+1)
+	for _, column := range table.Columns {
+		definition, err := columnDefinition(column)
+		if err != nil {
+			return "", err
+		}
+		definitions = append(definitions, definition)
+	}
+	definitions = append(definitions, "PRIMARY KEY ("+primaryKey(table)+")")
+
+	var options []option
+	if len(table.ClusteringKey) > 0 {
+		options = append(options, clusteringOrderOption(table.ClusteringKey))
+	}
+	options = append(options, table.Options.options()...)
+	return newStatement("CREATE TABLE").
+		qualifiedIdentifier(table.Keyspace, table.Name).
+		keyword("(" + strings.Join(definitions, ", ") + ")").
+		with(options...).
+		String(), nil
+}
+
+// alterTableColumnsStatements returns the statements adding and dropping
+// columns of a table.
+func alterTableColumnsStatements(table Table, add []Column, drop []string) ([]string, error) {
+	var statements []string
+	if len(add) > 0 {
+		definitions := make([]string, 0, len(add))
+		for _, column := range add {
+			definition, err := columnDefinition(column)
+			if err != nil {
+				return nil, err
+			}
+			definitions = append(definitions, definition)
+		}
+		statements = append(statements, newStatement("ALTER TABLE").
+			qualifiedIdentifier(table.Keyspace, table.Name).
+			keyword("ADD", "("+strings.Join(definitions, ", ")+")").
+			String())
+	}
+	if len(drop) > 0 {
+		statements = append(statements, newStatement("ALTER TABLE").
+			qualifiedIdentifier(table.Keyspace, table.Name).
+			keyword("DROP", "("+quoteIdentifiers(drop)+")").
+			String())
+	}
+	return statements, nil
+}
+
+// alterTableOptionsStatement returns the statement setting the options of a
+// table that are set.
+func alterTableOptionsStatement(table Table) string {
+	return newStatement("ALTER TABLE").
+		qualifiedIdentifier(table.Keyspace, table.Name).
+		with(table.Options.options()...).
+		String()
+}
+
+// columnDefinition renders a column of a CREATE TABLE or ALTER TABLE ADD
+// statement, with its type in canonical form.
+func columnDefinition(column Column) (string, error) {
+	columnType, err := ParseType(column.Type)
+	if err != nil {
+		return "", fmt.Errorf("column %s: %w", column.Name, err)
+	}
+	definition := QuoteIdentifier(column.Name) + " " + columnType.String()
+	if column.Static {
+		definition += " STATIC"
+	}
+	return definition, nil
+}
+
+// primaryKey renders the columns of the PRIMARY KEY clause, with the
+// partition key always in parentheses.
+func primaryKey(table Table) string {
+	key := "(" + quoteIdentifiers(table.PartitionKey) + ")"
+	if len(table.ClusteringKey) > 0 {
+		key += ", " + quoteIdentifiers(clusteringNames(table.ClusteringKey))
+	}
+	return key
+}
+
+func clusteringOrderOption(key []ClusteringColumn) option {
+	orders := make([]string, 0, len(key))
+	for _, c := range key {
+		orders = append(orders, QuoteIdentifier(c.Name)+" "+c.Order())
+	}
+	return option{name: "CLUSTERING ORDER BY (" + strings.Join(orders, ", ") + ")"}
+}
+
+func clusteringNames(key []ClusteringColumn) []string {
+	names := make([]string, 0, len(key))
+	for _, c := range key {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+func quoteIdentifiers(names []string) string {
+	quoted := make([]string, 0, len(names))
+	for _, name := range names {
+		quoted = append(quoted, QuoteIdentifier(name))
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,192 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package scylladb
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func eventsTable() Table {
+	return Table{
+		Keyspace: "app_ks",
+		Name:     "events",
+		Columns: []Column{
+			{Name: "tenant", Type: "text"},
+			{Name: "day", Type: "date"},
+			{Name: "ts", Type: "timeuuid"},
+			{Name: "payload", Type: "frozen<map<text, int>>"},
+			{Name: "owner", Type: "text", Static: true},
+		},
+		PartitionKey:  []string{"tenant", "day"},
+		ClusteringKey: []ClusteringColumn{{Name: "ts", Descending: true}},
+	}
+}
+
+func TestTableValidate(t *testing.T) {
+	assert.NoError(t, eventsTable().Validate())
+
+	tests := map[string]func(*Table){
+		"no partition key":    func(t *Table) { t.PartitionKey = nil },
+		"unknown key column":  func(t *Table) { t.PartitionKey = []string{"missing"} },
+		"repeated key column": func(t *Table) { t.ClusteringKey = []ClusteringColumn{{Name: "tenant"}} },
+		"duplicate column":    func(t *Table) { t.Columns = append(t.Columns, Column{Name: "ts", Type: "int"}) },
+		"static key column":   func(t *Table) { t.Columns[0].Static = true },
+		"static without clustering": func(t *Table) {
+			t.ClusteringKey = nil
+			t.Columns = slices.DeleteFunc(t.Columns, func(c Column) bool { return c.Name == "ts" })
+		},
+		"invalid type": func(t *Table) { t.Columns[3].Type = "int); DROP TABLE x; --" },
+		"empty type":   func(t *Table) { t.Columns[3].Type = " " },
+	}
+	for name, mutate := range tests {
+		table := eventsTable()
+		mutate(&table)
+		assert.Error(t, table.Validate(), name)
+	}
+}
+
+func TestTableStatements(t *testing.T) {
+	table := eventsTable()
+	stmt, err := createTableStatement(table)
+	assert.NoError(t, err)
+	assert.Equal(t,
+		`CREATE TABLE "app_ks"."events" ("tenant" text, "day" date, "ts" timeuuid, "payload" frozen<map<text, int>>, "owner" text STATIC, `+
+			`PRIMARY KEY (("tenant", "day"), "ts")) WITH CLUSTERING ORDER BY ("ts" DESC)`,
+		stmt)
+
+	table.ClusteringKey = nil
+	table.Columns = table.Columns[:2]
+	table.PartitionKey = []string{"tenant"}
+	table.Columns[0].Type = "VARCHAR"
+	stmt, err = createTableStatement(table)
+	assert.NoError(t, err)
+	assert.Equal(t,
+		`CREATE TABLE "app_ks"."events" ("tenant" text, "day" date, PRIMARY KEY (("tenant")))`,
+		stmt)
+
+	table.Columns[1].Type = "map<text"
+	_, err = createTableStatement(table)
+	assert.Error(t, err)
+
+	statements, err := alterTableColumnsStatements(table, []Column{{Name: "a", Type: "Frozen<List<INT>>"}, {Name: "b", Type: "text", Static: true}}, []string{"c", "d"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		`ALTER TABLE "app_ks"."events" ADD ("a" frozen<list<int>>, "b" text STATIC)`,
+		`ALTER TABLE "app_ks"."events" DROP ("c", "d")`,
+	}, statements)
+
+	_, err = alterTableColumnsStatements(table, []Column{{Name: "a", Type: "int) STATIC"}}, nil)
+	assert.Error(t, err)
+}
+
+func TestDiffColumns(t *testing.T) {
+	current := eventsTable()
+	desired := eventsTable()
+	desired.Columns = append(desired.Columns[:3], Column{Name: "source", Type: "inet"})
+	desired.Columns[0].Type = "TEXT"
+
+	add, drop, err := DiffColumns(current, desired)
+	assert.NoError(t, err)
+	assert.Equal(t, []Column{{Name: "source", Type: "inet"}}, add)
+	assert.Equal(t, []string{"payload", "owner"}, drop)
+
+	desired = eventsTable()
+	desired.Columns[3].Type = "frozen<map<text, bigint>>"
+	_, _, err = DiffColumns(current, desired)
+	assert.EqualError(t, err, "the type of column payload cannot change from frozen<map<text, int>> to frozen<map<text, bigint>>")
+
+	desired = eventsTable()
+	desired.Columns = desired.Columns[1:]
+	_, _, err = DiffColumns(current, desired)
+	assert.EqualError(t, err, "column tenant is part of the primary key and cannot be dropped")
+}
+
+func TestTable(t *testing.T) {
+	cluster := newTestCluster(t)
+	defer cluster.Session.Close()
+
+	assert.NoError(t, cluster.CreateKeyspace(Keyspace{
+		Name:          "app_ks",
+		Replication:   Replication{Class: NetworkTopologyStrategy, DataCenters: map[string]int{"datacenter1": 1}},
+		DurableWrites: true,
+	}))
+	table := eventsTable()
+	comment := "events by tenant"
+	table.Options = TableOptions{
+		Comment:    &comment,
+		Compaction: &Compaction{Class: LeveledCompactionStrategy, Options: map[string]string{"sstable_size_in_mb": "128"}},
+	}
+	assert.NoError(t, cluster.CreateTable(table))
+
+	got, err := cluster.GetTable("app_ks", "events")
+	assert.NoError(t, err)
+	assert.Equal(t, comment, *got.Options.Comment)
+	assert.Equal(t, LeveledCompactionStrategy, got.Options.Compaction.Class)
+	assert.Equal(t, "128", got.Options.Compaction.Options["sstable_size_in_mb"])
+	assert.NotNil(t, got.Options.GCGraceSeconds)
+	got.Options = TableOptions{}
+	// Non-key columns are read back sorted by name
+	expected := eventsTable()
+	expected.Columns = []Column{
+		{Name: "tenant", Type: "text"},
+		{Name: "day", Type: "date"},
+		{Name: "ts", Type: "timeuuid"},
+		{Name: "owner", Type: "text", Static: true},
+		{Name: "payload", Type: "frozen<map<text, int>>"},
+	}
+	assert.Equal(t, expected, got)
+
+	table.Columns = append(table.Columns[:3], Column{Name: "source", Type: "inet"})
+	gcGraceSeconds := 3600
+	table.Options = TableOptions{GCGraceSeconds: &gcGraceSeconds}
+	assert.NoError(t, cluster.AlterTable(table))
+	got, err = cluster.GetTable("app_ks", "events")
+	assert.NoError(t, err)
+	assert.Equal(t, gcGraceSeconds, *got.Options.GCGraceSeconds)
+	assert.Equal(t, comment, *got.Options.Comment)
+	got.Options = TableOptions{}
+	table.Options = TableOptions{}
+	assert.Equal(t, table, got)
+
+	assert.NoError(t, cluster.DropTable("app_ks", "events"))
+	_, err = cluster.GetTable("app_ks", "events")
+	assert.ErrorIs(t, err, ErrTableNotFound)
+	assert.EqualError(t, err, "table not found: app_ks.events")
+}
+
+func TestNonEmptyTable(t *testing.T) {
+	cluster := newTestCluster(t)
+	defer cluster.Session.Close()
+
+	assert.NoError(t, cluster.CreateKeyspace(Keyspace{
+		Name:          "data_ks",
+		Replication:   Replication{Class: NetworkTopologyStrategy, DataCenters: map[string]int{"datacenter1": 1}},
+		DurableWrites: true,
+	}))
+	for _, query := range []string{
+		`CREATE TABLE "data_ks"."a" (id int PRIMARY KEY)`,
+		`CREATE TABLE "data_ks"."b" (id int PRIMARY KEY)`,
+	} {
+		assert.NoError(t, cluster.Session.Query(query).Exec())
+	}
+
+	tables, err := cluster.ListTables("data_ks")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, tables)
+
+	table, err := cluster.NonEmptyTable("data_ks")
+	assert.NoError(t, err)
+	assert.Empty(t, table)
+
+	assert.NoError(t, cluster.Session.Query(`INSERT INTO "data_ks"."b" (id) VALUES (1)`).Exec())
+	empty, err := cluster.IsTableEmpty("data_ks", "b")
+	assert.NoError(t, err)
+	assert.False(t, empty)
+	table, err = cluster.NonEmptyTable("data_ks")
+	assert.NoError(t, err)
+	assert.Equal(t, "b", table)
+}
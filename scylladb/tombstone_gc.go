@@ -0,0 +1,112 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package scylladb
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Modes of the garbage collection of tombstones.
+const (
+	// TombstoneGCTimeout drops tombstones once gc_grace_seconds have passed.
+	TombstoneGCTimeout = "timeout"
+	// TombstoneGCRepair drops tombstones once the table has been repaired.
+	TombstoneGCRepair = "repair"
+	// TombstoneGCImmediate drops tombstones as soon as possible.
+	TombstoneGCImmediate = "immediate"
+	// TombstoneGCDisabled never drops tombstones.
+	TombstoneGCDisabled = "disabled"
+)
+
+// TombstoneGCModes lists the modes of the garbage collection of tombstones.
+var TombstoneGCModes = []string{TombstoneGCTimeout, TombstoneGCRepair, TombstoneGCImmediate, TombstoneGCDisabled}
+
+// TombstoneGC is the garbage collection of the tombstones of a table.
+type TombstoneGC struct {
+	Mode string
+	// PropagationDelay is how long in seconds a tombstone is kept after the
+	// repair that covers it in repair mode. Nil is left to the cluster.
+	PropagationDelay *int
+}
+
+// Validate checks the mode and the propagation delay.
+func (t TombstoneGC) Validate() error {
+	if !slices.Contains(TombstoneGCModes, strings.ToLower(t.Mode)) {
+		return fmt.Errorf("invalid tombstone_gc mode %q, expected one of %s", t.Mode, strings.Join(TombstoneGCModes, ", "))
+	}
+	if t.PropagationDelay != nil && *t.PropagationDelay < 0 {
+		return errors.New("the tombstone_gc propagation delay cannot be negative")
+	}
+	return nil
+}
+
+// options renders the garbage collection as the map of a
+// `tombstone_gc = {...}` option.
+func (t TombstoneGC) options() map[string]string {
+	options := map[string]string{"mode": strings.ToLower(t.Mode)}
+	if t.PropagationDelay != nil {
+		options["propagation_delay_in_seconds"] = strconv.Itoa(*t.PropagationDelay)
+	}
+	return options
+}
+
+// parseTombstoneGC parses the options of the tombstone_gc extension of a
+// table. The defaults depend on the cluster and on the keyspace, so a
+// missing propagation delay is left nil rather than assumed.
+func parseTombstoneGC(options map[string]string) TombstoneGC {
+	gc := TombstoneGC{Mode: options["mode"]}
+	if delay, err := strconv.Atoi(options["propagation_delay_in_seconds"]); err == nil {
+		gc.PropagationDelay = &delay
+	}
+	return gc
+}
+
+// PerPartitionRateLimit limits the reads and the writes of each partition of
+// a table, rejecting the operations above the limits. Nil limits are not
+// enforced.
+type PerPartitionRateLimit struct {
+	MaxReadsPerSecond  *int
+	MaxWritesPerSecond *int
+}
+
+// Validate checks that the limits are positive.
+func (l PerPartitionRateLimit) Validate() error {
+	if l.MaxReadsPerSecond != nil && *l.MaxReadsPerSecond < 1 {
+		return errors.New("max_reads_per_second must be positive")
+	}
+	if l.MaxWritesPerSecond != nil && *l.MaxWritesPerSecond < 1 {
+		return errors.New("max_writes_per_second must be positive")
+	}
+	return nil
+}
+
+// options renders the limits as the map of a
+// `per_partition_rate_limit = {...}` option. An empty map removes the limits.
+func (l PerPartitionRateLimit) options() map[string]string {
+	options := map[string]string{}
+	if l.MaxReadsPerSecond != nil {
+		options["max_reads_per_second"] = strconv.Itoa(*l.MaxReadsPerSecond)
+	}
+	if l.MaxWritesPerSecond != nil {
+		options["max_writes_per_second"] = strconv.Itoa(*l.MaxWritesPerSecond)
+	}
+	return options
+}
+
+// parsePerPartitionRateLimit parses the options of the
+// per_partition_rate_limit extension of a table.
+func parsePerPartitionRateLimit(options map[string]string) PerPartitionRateLimit {
+	var limit PerPartitionRateLimit
+	if reads, err := strconv.Atoi(options["max_reads_per_second"]); err == nil {
+		limit.MaxReadsPerSecond = &reads
+	}
+	if writes, err := strconv.Atoi(options["max_writes_per_second"]); err == nil {
+		limit.MaxWritesPerSecond = &writes
+	}
+	return limit
+}
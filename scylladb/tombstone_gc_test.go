@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package scylladb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTombstoneGC(t *testing.T) {
+	delay, zero, negative := 600, 0, -1
+	assert.NoError(t, TombstoneGC{Mode: "REPAIR", PropagationDelay: &delay}.Validate())
+	assert.NoError(t, TombstoneGC{Mode: "REPAIR", PropagationDelay: &zero}.Validate())
+	assert.EqualError(t, TombstoneGC{Mode: "never"}.Validate(), `invalid tombstone_gc mode "never", expected one of timeout, repair, immediate, disabled`)
+	assert.Error(t, TombstoneGC{Mode: TombstoneGCTimeout, PropagationDelay: &negative}.Validate())
+
+	assert.Equal(t, map[string]string{"mode": "repair", "propagation_delay_in_seconds": "600"}, TombstoneGC{Mode: "Repair", PropagationDelay: &delay}.options())
+	assert.Equal(t, map[string]string{"mode": "repair", "propagation_delay_in_seconds": "0"}, TombstoneGC{Mode: "repair", PropagationDelay: &zero}.options())
+	assert.Equal(t, map[string]string{"mode": "disabled"}, TombstoneGC{Mode: TombstoneGCDisabled}.options())
+
+	parsed := 60
+	assert.Equal(t, TombstoneGC{Mode: TombstoneGCRepair, PropagationDelay: &parsed},
+		parseTombstoneGC(map[string]string{"mode": "repair", "propagation_delay_in_seconds": "60"}))
+	assert.Equal(t, TombstoneGC{Mode: TombstoneGCRepair}, parseTombstoneGC(map[string]string{"mode": "repair"}))
+}
+
+func TestPerPartitionRateLimit(t *testing.T) {
+	writes, zero := 100, 0
+	assert.NoError(t, PerPartitionRateLimit{MaxWritesPerSecond: &writes}.Validate())
+	assert.EqualError(t, PerPartitionRateLimit{MaxReadsPerSecond: &zero}.Validate(), "max_reads_per_second must be positive")
+
+	assert.Equal(t, map[string]string{"max_writes_per_second": "100"}, PerPartitionRateLimit{MaxWritesPerSecond: &writes}.options())
+	assert.Equal(t, map[string]string{}, PerPartitionRateLimit{}.options())
+
+	limit := parsePerPartitionRateLimit(map[string]string{"max_writes_per_second": "100"})
+	assert.Nil(t, limit.MaxReadsPerSecond)
+	assert.Equal(t, 100, *limit.MaxWritesPerSecond)
+	assert.Equal(t, PerPartitionRateLimit{}, parsePerPartitionRateLimit(nil))
+}
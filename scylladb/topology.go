@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package scylladb
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Topology maps each data center of the cluster to its racks, sorted by
+// name.
+type Topology map[string][]string
+
+// GetTopology reads the data centers and racks of the node the session is
+// connected to and of its peers.
+func (c *Cluster) GetTopology() (Topology, error) {
+	topology := Topology{}
+	for _, table := range []string{"local", "peers"} {
+		query := newStatement("SELECT data_center, rack FROM").qualifiedIdentifier("system", table).String()
+		iter := c.Session.Query(query).Iter()
+		var dc, rack string
+		for iter.Scan(&dc, &rack) {
+			if !slices.Contains(topology[dc], rack) {
+				topology[dc] = append(topology[dc], rack)
+			}
+		}
+		if err := iter.Close(); err != nil {
+			return nil, fmt.Errorf("failed to read the topology from system.%s: %w", table, err)
+		}
+	}
+	for dc := range topology {
+		slices.Sort(topology[dc])
+	}
+	return topology, nil
+}
+
+// CheckReplication checks that the data centers and racks a
+// NetworkTopologyStrategy replication refers to exist in the topology.
+// Other classes are not tied to the topology.
+func (t Topology) CheckReplication(r Replication) error {
+	if NormalizeReplicationClass(r.Class) != NetworkTopologyStrategy {
+		return nil
+	}
+	dataCenters := slices.Collect(maps.Keys(r.DataCenters))
+	dataCenters = append(dataCenters, slices.Collect(maps.Keys(r.Racks))...)
+	slices.Sort(dataCenters)
+	for _, dc := range dataCenters {
+		racks, ok := t[dc]
+		if !ok {
+			return fmt.Errorf("unknown data center %s, the cluster has %s", dc, t.dataCenters())
+		}
+		for _, rack := range sortedRacks(r.Racks[dc]) {
+			if !slices.Contains(racks, rack) {
+				return fmt.Errorf("unknown rack %s in data center %s, it has %s", rack, dc, strings.Join(racks, ", "))
+			}
+		}
+	}
+	return nil
+}
+
+func (t Topology) dataCenters() string {
+	dataCenters := slices.Collect(maps.Keys(t))
+	slices.Sort(dataCenters)
+	return strings.Join(dataCenters, ", ")
+}
@@ -0,0 +1,37 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package scylladb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTopologyCheckReplication(t *testing.T) {
+	topology := Topology{"dc1": {"rack1", "rack2"}, "dc2": {"rack1"}}
+
+	assert.NoError(t, topology.CheckReplication(Replication{
+		Class:       NetworkTopologyStrategy,
+		DataCenters: map[string]int{"dc2": 3},
+		Racks:       map[string][]string{"dc1": {"rack2", "rack1"}},
+	}))
+	assert.NoError(t, topology.CheckReplication(Replication{Class: SimpleStrategy, ReplicationFactor: 3}))
+
+	assert.EqualError(t,
+		topology.CheckReplication(Replication{Class: NetworkTopologyStrategy, DataCenters: map[string]int{"dc3": 1}}),
+		"unknown data center dc3, the cluster has dc1, dc2")
+	assert.EqualError(t,
+		topology.CheckReplication(Replication{Class: NetworkTopologyStrategy, Racks: map[string][]string{"dc2": {"rack2"}}}),
+		"unknown rack rack2 in data center dc2, it has rack1")
+}
+
+func TestGetTopology(t *testing.T) {
+	cluster := newTestCluster(t)
+	defer cluster.Session.Close()
+
+	topology, err := cluster.GetTopology()
+	assert.NoError(t, err)
+	assert.Equal(t, Topology{"datacenter1": {"rack1"}}, topology)
+}